@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/n3sty/focus/internal/activity"
+	"github.com/spf13/cobra"
+)
+
+var activityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Record terminal activity (called from the shell hook)",
+	Long: `Marks the current moment as active so the watcher doesn't treat you as idle.
+
+Add it to your shell prompt hook, for example in ~/.zshrc:
+  precmd() { focus activity 2>/dev/null }`,
+	Hidden: true, // Called by shell hooks, not by hand
+	RunE:   runActivity,
+}
+
+func init() {
+	rootCmd.AddCommand(activityCmd)
+}
+
+func runActivity(cmd *cobra.Command, args []string) error {
+	return activity.Touch()
+}
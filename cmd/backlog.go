@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/backlog"
+	"github.com/spf13/cobra"
+)
+
+var backlogCmd = &cobra.Command{
+	Use:   "backlog",
+	Short: "List ideas saved for later",
+	Long: `Lists the backlog by priority. Capture ideas with 'focus later' and turn
+one into a session with 'focus start --from-backlog <id>'.`,
+	Args: cobra.NoArgs,
+	RunE: runBacklog,
+}
+
+var backlogPriorityCmd = &cobra.Command{
+	Use:   "priority <id> <high|medium|low>",
+	Short: "Change an item's priority",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runBacklogPriority,
+}
+
+var backlogEstimateCmd = &cobra.Command{
+	Use:   "estimate <id> <duration>",
+	Short: "Set an item's timebox estimate",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runBacklogEstimate,
+}
+
+var backlogNoteCmd = &cobra.Command{
+	Use:   "note <id> <text>",
+	Short: "Add a note to an item",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runBacklogNote,
+}
+
+var backlogLinkCmd = &cobra.Command{
+	Use:   "link <id> <url>",
+	Short: "Attach a link to an item",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runBacklogLink,
+}
+
+var backlogDropCmd = &cobra.Command{
+	Use:   "drop <id>",
+	Short: "Remove an item from the backlog",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBacklogDrop,
+}
+
+func init() {
+	backlogCmd.AddCommand(backlogPriorityCmd)
+	backlogCmd.AddCommand(backlogEstimateCmd)
+	backlogCmd.AddCommand(backlogNoteCmd)
+	backlogCmd.AddCommand(backlogLinkCmd)
+	backlogCmd.AddCommand(backlogDropCmd)
+	rootCmd.AddCommand(backlogCmd)
+}
+
+func runBacklog(cmd *cobra.Command, args []string) error {
+	b, err := backlog.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load backlog: %w", err)
+	}
+
+	if len(b.Items) == 0 {
+		fmt.Println("Backlog is empty. Capture ideas with 'focus later \"idea\"'")
+		return nil
+	}
+
+	icons := map[backlog.Priority]string{
+		backlog.PriorityHigh:   "🔴",
+		backlog.PriorityMedium: "🟡",
+		backlog.PriorityLow:    "⚪",
+	}
+
+	fmt.Println("\n📥 Backlog")
+	for _, item := range b.Sorted() {
+		fmt.Printf("\n  %s #%d %s", icons[item.Priority], item.ID, item.Title)
+		if item.Estimate != "" {
+			fmt.Printf(" (~%s)", item.Estimate)
+		}
+		fmt.Println()
+		fmt.Printf("     Added %s\n", item.Created.Format("Jan 2 15:04"))
+		for _, note := range item.Notes {
+			fmt.Printf("     • %s\n", note)
+		}
+		for _, link := range item.Links {
+			fmt.Printf("     🔗 %s\n", link)
+		}
+	}
+
+	fmt.Println("\nStart one with: focus start --from-backlog <id>")
+	return nil
+}
+
+func runBacklogPriority(cmd *cobra.Command, args []string) error {
+	priority, err := backlog.ParsePriority(args[1])
+	if err != nil {
+		return err
+	}
+	return updateBacklogItem(args[0], func(item *backlog.Item) {
+		item.Priority = priority
+	})
+}
+
+func runBacklogEstimate(cmd *cobra.Command, args []string) error {
+	if _, err := time.ParseDuration(args[1]); err != nil {
+		return fmt.Errorf("invalid estimate %q (e.g., 45m, 2h)", args[1])
+	}
+	return updateBacklogItem(args[0], func(item *backlog.Item) {
+		item.Estimate = args[1]
+	})
+}
+
+func runBacklogNote(cmd *cobra.Command, args []string) error {
+	return updateBacklogItem(args[0], func(item *backlog.Item) {
+		item.Notes = append(item.Notes, strings.TrimSpace(args[1]))
+	})
+}
+
+func runBacklogLink(cmd *cobra.Command, args []string) error {
+	return updateBacklogItem(args[0], func(item *backlog.Item) {
+		item.Links = append(item.Links, strings.TrimSpace(args[1]))
+	})
+}
+
+func runBacklogDrop(cmd *cobra.Command, args []string) error {
+	id, err := parseBacklogID(args[0])
+	if err != nil {
+		return err
+	}
+
+	b, err := backlog.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load backlog: %w", err)
+	}
+	if err := b.Remove(id); err != nil {
+		return err
+	}
+	if err := b.Save(); err != nil {
+		return fmt.Errorf("failed to save backlog: %w", err)
+	}
+
+	fmt.Printf("✓ Dropped #%d\n", id)
+	return nil
+}
+
+// updateBacklogItem applies change to one item and saves the backlog
+func updateBacklogItem(arg string, change func(item *backlog.Item)) error {
+	id, err := parseBacklogID(arg)
+	if err != nil {
+		return err
+	}
+
+	b, err := backlog.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load backlog: %w", err)
+	}
+
+	item, err := b.Get(id)
+	if err != nil {
+		return err
+	}
+	change(item)
+
+	if err := b.Save(); err != nil {
+		return fmt.Errorf("failed to save backlog: %w", err)
+	}
+
+	fmt.Printf("✓ Updated #%d %s\n", item.ID, item.Title)
+	return nil
+}
+
+// parseBacklogID accepts "3" or "#3"
+func parseBacklogID(arg string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid backlog id %q", arg)
+	}
+	return id, nil
+}
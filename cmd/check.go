@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/hooks"
 	"github.com/n3sty/focus/internal/session"
 	"github.com/n3sty/focus/internal/tui"
 	"github.com/spf13/cobra"
@@ -14,11 +18,15 @@ var checkCmd = &cobra.Command{
 	Short: "Check if you're still focused on your goal",
 	Long: `Opens an interactive prompt to verify you're still working on your stated goal.
 
-If you've drifted, this helps you log the distraction and decide whether to continue or refocus.`,
+If you've drifted, this helps you log the distraction and decide whether to continue or refocus.
+Every answer is stored on the session, so you can see how often you defer.`,
 	RunE: runCheck,
 }
 
+var checkNote string
+
 func init() {
+	checkCmd.Flags().StringVar(&checkNote, "note", "", "Note to store with your answer")
 	rootCmd.AddCommand(checkCmd)
 }
 
@@ -29,8 +37,17 @@ func runCheck(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
 	}
 
+	// Pick up a branch switch the watcher hasn't noticed yet
+	if err := trackBranch(sess); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	// Remember where the user drifted to, in case they switch back
+	offBranch := sess.OffBranch
+	base := sess.Clone()
+
 	// Launch TUI
-	model := tui.NewCheckModel(sess)
+	model := tui.NewCheckModel(sess, checkNote)
 	p := tea.NewProgram(model)
 
 	finalModel, err := p.Run()
@@ -39,13 +56,72 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save any changes
-	if m, ok := finalModel.(tui.CheckModel); ok {
-		if m.Updated {
-			if err := sess.Save(); err != nil {
-				return fmt.Errorf("failed to save session: %w", err)
+	m, ok := finalModel.(tui.CheckModel)
+	if !ok {
+		return nil
+	}
+
+	if m.Updated {
+		syncSession(sess, base)
+		if err := sess.Save(); err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
+	}
+
+	for _, wasBreak := range m.IdleReviews() {
+		record(sess, events.IdleReviewed, map[string]string{"break": strconv.FormatBool(wasBreak)})
+	}
+
+	for _, step := range m.StepsDone() {
+		recordStep(sess, events.StepDone, step)
+	}
+
+	answer := m.Answer()
+	if answer == "" {
+		return nil
+	}
+
+	data := map[string]string{"answer": answer, "source": "check"}
+	if checkNote != "" {
+		data["note"] = checkNote
+	}
+	if c := sess.LastCheckIn(); c != nil && c.Prompted() {
+		data["latency"] = c.Latency.Round(time.Second).String()
+	}
+	record(sess, events.CheckAnswered, data)
+	runHook(hooks.OnCheck, sess, map[string]string{"FOCUS_ANSWER": answer})
+
+	if answer == "switch" && offBranch != nil {
+		drift := sess.Drifts[len(sess.Drifts)-1]
+		record(sess, events.BranchReturned, map[string]string{
+			"branch":   offBranch.Branch,
+			"duration": time.Since(offBranch.Since).Round(time.Second).String(),
+		})
+		record(sess, events.DriftLogged, drift.Fields())
+		driftHook(sess, drift)
+	}
+
+	if answer == "no" {
+		drift := sess.Drifts[len(sess.Drifts)-1]
+		record(sess, events.DriftLogged, drift.Fields())
+		driftHook(sess, drift)
+
+		if park, withChanges := m.Park(); park {
+			if err := parkDrift(sess, drift, withChanges); err != nil {
+				return err
 			}
 		}
 	}
 
 	return nil
 }
+
+// syncSession folds in whatever the watcher saved while the user sat in
+// a TUI, so the save that follows doesn't overwrite it
+func syncSession(sess, base *session.Session) {
+	latest, err := session.LoadByID(sess.ID)
+	if err != nil {
+		return
+	}
+	sess.Merge(base, latest)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/n3sty/focus/internal/daemon"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/notify"
+	"github.com/n3sty/focus/internal/session"
+	"github.com/n3sty/focus/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var dashCmd = &cobra.Command{
+	Use:   "dash",
+	Short: "Open a live dashboard for the current session",
+	Long: `Opens a live dashboard with a countdown to the end of your timebox,
+focused time, the latest commits on the focus branch, the drift log and
+the watcher's state. It refreshes every second and whenever the session
+changes on disk.
+
+Keys:
+  c  check in (same as 'focus check')
+  e  extend the timebox by 15 minutes
+  p  pause the session
+  x  end the session (same as 'focus end')
+  r  refresh now
+  q  quit the dashboard`,
+	RunE: runDash,
+}
+
+// dashExtendBy is how much the extend key adds to the timebox
+const dashExtendBy = 15 * time.Minute
+
+func init() {
+	rootCmd.AddCommand(dashCmd)
+}
+
+func runDash(cmd *cobra.Command, args []string) error {
+	for {
+		sess, err := session.Load()
+		if err != nil || sess.Status != "active" {
+			return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
+		}
+
+		snapshot, err := loadDash()
+		if err != nil {
+			return fmt.Errorf("failed to load session: %w", err)
+		}
+
+		model := tui.NewDashModel(snapshot, loadDash)
+		p := tea.NewProgram(model, tea.WithAltScreen())
+
+		finalModel, err := p.Run()
+		if err != nil {
+			return fmt.Errorf("error running TUI: %w", err)
+		}
+
+		m, ok := finalModel.(tui.DashModel)
+		if !ok {
+			return nil
+		}
+
+		// Run the chosen action, then come back to the dashboard while
+		// the session is still active
+		switch m.Action() {
+		case tui.DashCheck:
+			if err := runCheck(cmd, nil); err != nil {
+				return err
+			}
+		case tui.DashExtend:
+			if err := extendSession(dashExtendBy); err != nil {
+				return err
+			}
+		case tui.DashPause:
+			return pauseSession()
+		case tui.DashEnd:
+			if err := runEnd(cmd, nil); err != nil {
+				return err
+			}
+			if sess, err := session.Load(); err != nil || sess.Status != "active" {
+				return nil
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// loadDash reads the active session with its commits and watcher state
+func loadDash() (tui.DashSnapshot, error) {
+	sess, err := session.Load()
+	if err != nil {
+		return tui.DashSnapshot{}, err
+	}
+
+	commits, err := git.GetCommits(sess.Branch, sess.StartTime)
+	if err != nil {
+		commits = nil // Non-fatal, just show none
+	}
+
+	return tui.DashSnapshot{
+		Session:  sess,
+		Commits:  commits,
+		Watching: daemon.IsRunning(),
+	}, nil
+}
+
+// extendSession adds d to the active session's timebox
+func extendSession(d time.Duration) error {
+	sess, err := session.Load()
+	if err != nil {
+		return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
+	}
+
+	if err := sess.Extend(d); err != nil {
+		return err
+	}
+	if err := sess.Save(); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	record(sess, events.SessionExtended, map[string]string{"timebox": sess.TimeBox, "source": "dash"})
+	announce(sess, notify.EventExtended, "")
+	return nil
+}
+
+// pauseSession pauses the active session so it can be resumed later
+func pauseSession() error {
+	sess, err := session.Load()
+	if err != nil {
+		return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
+	}
+
+	if err := sess.Pause(); err != nil {
+		return fmt.Errorf("failed to pause session: %w", err)
+	}
+	publish(sess, notify.EventPaused, "")
+
+	fmt.Println("Session paused. Run 'focus resume' to continue later.")
+	return nil
+}
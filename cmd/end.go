@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/n3sty/focus/internal/daemon"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/hooks"
+	"github.com/n3sty/focus/internal/notify"
 	"github.com/n3sty/focus/internal/session"
 	"github.com/n3sty/focus/internal/tui"
 	"github.com/spf13/cobra"
@@ -19,11 +23,18 @@ You'll be able to:
 - Review what you accomplished
 - Merge to main if goal is complete
 - Continue tomorrow if still in progress
-- Abandon the branch if it was a rabbit hole`,
+- Abandon the branch if it was a rabbit hole
+
+After picking an action you can jot down what got done, what blocked you,
+what you learned and rate your focus from 1 to 5. Reflections are kept with
+the session and show up in 'focus history'.`,
 	RunE: runEnd,
 }
 
+var skipReflection bool
+
 func init() {
+	endCmd.Flags().BoolVar(&skipReflection, "no-reflect", false, "Skip the reflection questions")
 	rootCmd.AddCommand(endCmd)
 }
 
@@ -34,31 +45,80 @@ func runEnd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
 	}
 
+	base := sess.Clone()
+
 	// Launch TUI
-	model := tui.NewEndModel(sess)
+	model := tui.NewEndModel(sess, !skipReflection)
 	p := tea.NewProgram(model)
 
 	finalModel, err := p.Run()
+	// Quitting mid-check mustn't leave the test suite running
+	model.StopChecks()
 	if err != nil {
 		return fmt.Errorf("error running TUI: %w", err)
 	}
 
-	// Handle the user's choice
-	if m, ok := finalModel.(tui.EndModel); ok {
-		if err := m.HandleAction(); err != nil {
-			return err
+	m, ok := finalModel.(tui.EndModel)
+	if !ok {
+		return nil
+	}
+
+	// The watcher kept running while the checks and questions were up
+	syncSession(sess, base)
+
+	if met, total := sess.CriteriaMet(); total > 0 {
+		record(sess, events.CriteriaChecked, map[string]string{
+			"met":   strconv.Itoa(met),
+			"total": strconv.Itoa(total),
+		})
+	}
+
+	if !m.Confirmed() {
+		// Keep the latest criteria results with the session
+		if len(sess.Criteria) > 0 {
+			if err := sess.Save(); err != nil {
+				return fmt.Errorf("failed to save session: %w", err)
+			}
 		}
+		return nil
+	}
+
+	// Let pre-end hooks veto the chosen action
+	env := map[string]string{"FOCUS_ACTION": m.ActionName()}
+	if err := runHook(hooks.PreEnd, sess, env); err != nil {
+		return err
+	}
+
+	// Handle the user's choice
+	if err := m.HandleAction(); err != nil {
+		return err
+	}
+
+	runHook(hooks.PostEnd, sess, env)
+
+	if r := m.Reflection(); r != nil {
+		record(sess, events.Reflected, map[string]string{
+			"action": r.Action,
+			"rating": strconv.Itoa(r.Rating),
+		})
+	}
+
+	switch m.GetChoice() {
+	case 0:
+		publish(sess, notify.EventEnded, "completed")
+	case 1:
+		publish(sess, notify.EventPaused, "")
+	case 2:
+		publish(sess, notify.EventEnded, "abandoned")
 	}
 
 	// Stop watcher when session ends (for merge and abandon, not continue)
-	if m, ok := finalModel.(tui.EndModel); ok {
-		if m.GetChoice() != 1 { // 1 = continue
-			if daemon.IsRunning() {
-				if err := daemon.Stop(); err != nil {
-					fmt.Printf("⚠️  Warning: Could not stop watcher: %v\n", err)
-				} else {
-					fmt.Println("✓ Background watcher stopped")
-				}
+	if m.GetChoice() != 1 { // 1 = continue
+		if daemon.IsRunning() {
+			if err := daemon.Stop(); err != nil {
+				fmt.Printf("⚠️  Warning: Could not stop watcher: %v\n", err)
+			} else {
+				fmt.Println("✓ Background watcher stopped")
 			}
 		}
 	}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/n3sty/focus/internal/config"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/notify"
+	"github.com/n3sty/focus/internal/session"
+)
+
+// record appends an event to the session's event log. Logging problems
+// are reported but never fail the command.
+func record(sess *session.Session, t events.Type, data map[string]string) {
+	if err := events.Append(events.New(sess.ID, t, data)); err != nil {
+		fmt.Printf("⚠️  Warning: could not record event: %v\n", err)
+	}
+}
+
+// publish records a lifecycle event and tells configured webhooks about
+// it. Delivery problems are reported but never fail the command.
+func publish(sess *session.Session, t notify.EventType, outcome string) {
+	var data map[string]string
+	if outcome != "" {
+		data = map[string]string{"outcome": outcome}
+	}
+	record(sess, t, data)
+	announce(sess, t, outcome)
+}
+
+// announce queues a lifecycle event for configured webhooks without
+// recording it, for callers that log the event with their own data. The
+// watcher delivers the queue in the background, so commands never wait
+// on a slow endpoint.
+func announce(sess *session.Session, t notify.EventType, outcome string) {
+	settings, err := config.Load()
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+		return
+	}
+
+	hooks, err := notify.WebhooksFromConfig(settings.Webhooks)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	event := notify.NewEvent(t, sess)
+	event.Outcome = outcome
+
+	if err := hooks.Enqueue(event); err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+	}
+}
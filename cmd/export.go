@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/n3sty/focus/internal/export"
+	"github.com/n3sty/focus/internal/worklog"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export sessions and drifts to other tools",
+	Long: `Exports your focus sessions, including drifts and the intervals you were
+actually focused, as:
+
+  md    Markdown, one section per session
+  csv   One row per focused interval, for spreadsheets and timesheets
+  json  Full session data with focused intervals
+  ics   A calendar event per focused interval
+
+Example:
+  focus export --format csv --since 7d > week.csv
+  focus export --format ics --since 2026-03-01 -o focus.ics`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+var (
+	exportFormat string
+	exportSince  string
+	exportOutput string
+)
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "md", "Output format: md, csv, json or ics")
+	exportCmd.Flags().StringVar(&exportSince, "since", "", "Only sessions worked on since a date (2006-01-02) or how far back (e.g., 14d, 36h)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write to a file instead of stdout")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format, err := export.ParseFormat(exportFormat)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var from time.Time
+	if exportSince != "" {
+		if from, err = parseSince(exportSince, now); err != nil {
+			return err
+		}
+	}
+
+	entries, err := worklog.LoadAll(now)
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+	entries = export.Between(entries, from, now)
+
+	var out io.Writer = os.Stdout
+	if exportOutput != "" {
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", exportOutput, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := export.Write(out, format, entries); err != nil {
+		return fmt.Errorf("failed to export: %w", err)
+	}
+
+	if exportOutput != "" {
+		fmt.Printf("✓ Exported %d sessions to %s\n", len(entries), exportOutput)
+	}
+	return nil
+}
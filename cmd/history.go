@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/session"
+	"github.com/n3sty/focus/internal/worklog"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List ended sessions with their reflections",
+	Long: `Lists merged and discarded sessions, newest first, with how long they
+took, what you wrote down when ending them and any notes.`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+var historyLimit int
+
+func init() {
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 10, "How many sessions to show (0 for all)")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	archived, err := session.ListArchived()
+	if err != nil {
+		return fmt.Errorf("failed to list ended sessions: %w", err)
+	}
+
+	if len(archived) == 0 {
+		fmt.Println("No ended sessions yet. Finish one with 'focus end'")
+		return nil
+	}
+
+	shown := 0
+	for i := len(archived) - 1; i >= 0; i-- {
+		if historyLimit > 0 && shown == historyLimit {
+			fmt.Printf("\n…and %d older (use -n 0 to show all)\n", i+1)
+			break
+		}
+		printHistoryEntry(archived[i])
+		shown++
+	}
+
+	return nil
+}
+
+// printHistoryEntry shows one ended session
+func printHistoryEntry(sess *session.Session) {
+	icon := "✅"
+	if sess.Status == "abandoned" {
+		icon = "🗑️ "
+	}
+
+	fmt.Printf("\n%s %s  %s\n", icon, sess.StartTime.Format("Mon Jan 2 15:04"), sess.Task)
+
+	var details []string
+	if intervals, err := worklog.Load(sess, time.Now()); err == nil {
+		details = append(details, fmt.Sprintf("%s of %s", formatDuration(worklog.Total(intervals)), sess.TimeBox))
+	}
+	details = append(details, sess.Status)
+	if len(sess.Drifts) > 0 {
+		details = append(details, fmt.Sprintf("%d drifts", len(sess.Drifts)))
+	}
+	if done, total := sess.StepProgress(); total > 0 {
+		details = append(details, fmt.Sprintf("%d/%d steps", done, total))
+	}
+	fmt.Printf("   %s • %s\n", sess.ID, strings.Join(details, " • "))
+	if sess.Issue != nil {
+		fmt.Printf("   🔗 %s\n", issueLine(sess.Issue))
+	}
+
+	if r := sess.LastReflection(); r != nil {
+		printReflection(*r, "   ")
+	}
+	for _, note := range sess.Notes {
+		fmt.Printf("   📝 %s\n", note.Text)
+	}
+}
+
+// printReflection shows the answers that were given, indented by indent
+func printReflection(r session.Reflection, indent string) {
+	if r.Rating > 0 {
+		fmt.Printf("%sFocus:   %s %d/5\n", indent, strings.Repeat("★", r.Rating)+strings.Repeat("☆", 5-r.Rating), r.Rating)
+	}
+	if r.Done != "" {
+		fmt.Printf("%sDone:    %s\n", indent, r.Done)
+	}
+	if r.Blocked != "" {
+		fmt.Printf("%sBlocked: %s\n", indent, r.Blocked)
+	}
+	if r.Learned != "" {
+		fmt.Printf("%sLearned: %s\n", indent, r.Learned)
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/n3sty/focus/internal/config"
+	"github.com/n3sty/focus/internal/hooks"
+	"github.com/n3sty/focus/internal/session"
+)
+
+// runHook runs the user's hooks for event. For pre-* events the returned
+// error vetoes the action; other hook failures are only reported.
+func runHook(event string, sess *session.Session, env map[string]string) error {
+	err := runHooks(event, sess, env)
+	if err == nil {
+		return nil
+	}
+
+	if strings.HasPrefix(event, "pre-") {
+		return fmt.Errorf("❌ %w", err)
+	}
+
+	fmt.Printf("⚠️  Warning: %v\n", err)
+	return nil
+}
+
+func runHooks(event string, sess *session.Session, env map[string]string) error {
+	settings, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	runner, err := hooks.FromConfig(settings.Hooks)
+	if err != nil {
+		return err
+	}
+	runner.Stdout = os.Stdout
+	runner.Stderr = os.Stderr
+
+	return runner.Run(event, sess, env)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/n3sty/focus/internal/backlog"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var laterCmd = &cobra.Command{
+	Use:   "later [idea]",
+	Short: "Save an idea to the backlog without leaving your session",
+	Long: `Captures an idea in the backlog so it stops nagging you, without touching
+the current session or its branch.
+
+Example:
+  focus later "Cache OCR results"
+  focus later "Upgrade tesseract" --estimate 2h --priority high --link https://github.com/org/repo/issues/42`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLater,
+}
+
+var (
+	laterNote     string
+	laterEstimate string
+	laterPriority string
+	laterLinks    []string
+)
+
+func init() {
+	laterCmd.Flags().StringVarP(&laterNote, "note", "n", "", "Extra detail to remember")
+	laterCmd.Flags().StringVarP(&laterEstimate, "estimate", "e", "", "Timebox estimate (e.g., 45m, 2h)")
+	laterCmd.Flags().StringVarP(&laterPriority, "priority", "p", "medium", "Priority: high, medium or low")
+	laterCmd.Flags().StringArrayVar(&laterLinks, "link", nil, "Related URL (repeatable)")
+	rootCmd.AddCommand(laterCmd)
+}
+
+func runLater(cmd *cobra.Command, args []string) error {
+	priority, err := backlog.ParsePriority(laterPriority)
+	if err != nil {
+		return err
+	}
+	if laterEstimate != "" {
+		if _, err := time.ParseDuration(laterEstimate); err != nil {
+			return fmt.Errorf("invalid estimate %q (e.g., 45m, 2h)", laterEstimate)
+		}
+	}
+
+	b, err := backlog.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load backlog: %w", err)
+	}
+
+	item := backlog.Item{
+		Title:    args[0],
+		Estimate: laterEstimate,
+		Priority: priority,
+		Links:    laterLinks,
+	}
+	if laterNote != "" {
+		item.Notes = []string{laterNote}
+	}
+
+	// Remember where the idea came from
+	sess, err := session.Load()
+	if err == nil && sess.Status == "active" {
+		item.From = sess.ID
+	} else {
+		sess = nil
+	}
+
+	added := b.Add(item)
+	if err := b.Save(); err != nil {
+		return fmt.Errorf("failed to save backlog: %w", err)
+	}
+
+	if sess != nil {
+		record(sess, events.IdeaCaptured, map[string]string{"id": strconv.Itoa(added.ID), "title": added.Title})
+	}
+
+	fmt.Printf("📥 Saved to backlog as #%d\n", added.ID)
+	if sess != nil {
+		fmt.Printf("🎯 Back to: %s\n", sess.Task)
+	}
+	return nil
+}
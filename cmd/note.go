@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var noteCmd = &cobra.Command{
+	Use:   "note [text]",
+	Short: "Attach a note to the current session",
+	Long: `Jots a note down on the current session, e.g. a decision or something to
+mention in review. Without text, lists the session's notes.
+
+Example:
+  focus note "Tesseract needs 300dpi input, resize before OCR"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runNote,
+}
+
+func init() {
+	rootCmd.AddCommand(noteCmd)
+}
+
+func runNote(cmd *cobra.Command, args []string) error {
+	sess, err := session.Load()
+	if err != nil {
+		return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
+	}
+
+	if len(args) == 0 {
+		if len(sess.Notes) == 0 {
+			fmt.Println("No notes yet. Add one with 'focus note \"text\"'")
+			return nil
+		}
+		fmt.Println("\n📝 Notes:")
+		for _, note := range sess.Notes {
+			fmt.Printf("  [%s] %s\n", note.Time.Format("15:04"), note.Text)
+		}
+		return nil
+	}
+
+	text := strings.TrimSpace(args[0])
+	if text == "" {
+		return fmt.Errorf("note is empty")
+	}
+
+	sess.AddNote(text, time.Now())
+	if err := sess.Save(); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	record(sess, events.NoteAdded, map[string]string{"text": text})
+
+	fmt.Printf("✓ Noted (%d on this session)\n", len(sess.Notes))
+	return nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/session"
+)
+
+// parkDrift captures a drift as its own paused session on a fresh branch
+// off the base branch, optionally stashing uncommitted changes to restore
+// when it's resumed. The current session stays active.
+func parkDrift(sess *session.Session, drift session.Drift, withChanges bool) error {
+	base, err := git.BaseBranch()
+	if err != nil {
+		return fmt.Errorf("failed to park drift: %w", err)
+	}
+
+	branch, err := git.CreateParkedBranch(drift.Description, base)
+	if err != nil {
+		return fmt.Errorf("failed to park drift: %w", err)
+	}
+
+	now := time.Now()
+	parked := &session.Session{
+		ID:         session.GenerateID(drift.Description),
+		Task:       drift.Description,
+		StartTime:  now,
+		TimeBox:    defaultTimeBox,
+		Branch:     branch,
+		Drifts:     []session.Drift{},
+		Status:     "paused",
+		Pauses:     []session.Pause{{Start: now, Reason: "paused"}},
+		ParkedFrom: sess.ID,
+	}
+
+	if withChanges && git.HasChanges() {
+		parked.Stash, err = git.Stash("focus: parked " + drift.Description)
+		if err != nil {
+			return fmt.Errorf("failed to park drift: %w", err)
+		}
+	}
+
+	if err := parked.Save(); err != nil {
+		return fmt.Errorf("failed to save parked session: %w", err)
+	}
+
+	record(parked, events.SessionStarted, map[string]string{"parked_from": sess.ID})
+	record(parked, events.SessionPaused, nil)
+	record(sess, events.DriftParked, map[string]string{
+		"session": parked.ID,
+		"branch":  branch,
+		"stashed": strconv.FormatBool(parked.Stash != ""),
+	})
+
+	fmt.Printf("📌 Parked \"%s\" on %s (off %s)\n", parked.Task, branch, base)
+	if parked.Stash != "" {
+		fmt.Println("   Uncommitted changes were stashed and come back on 'focus resume'")
+	}
+	fmt.Printf("🎯 Back to: %s\n", sess.Task)
+	return nil
+}
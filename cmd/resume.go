@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/n3sty/focus/internal/notify"
 	"github.com/n3sty/focus/internal/session"
 	"github.com/n3sty/focus/internal/tui"
 	"github.com/spf13/cobra"
@@ -34,15 +35,7 @@ func runResume(cmd *cobra.Command, args []string) error {
 
 	// If only one paused session, resume it automatically
 	if len(sessions) == 1 {
-		sess := sessions[0]
-		if err := sess.Activate(); err != nil {
-			return fmt.Errorf("failed to resume session: %w", err)
-		}
-
-		fmt.Println("\nSession resumed:")
-		fmt.Printf("  Goal: %s\n", sess.Task)
-		fmt.Printf("  Branch: %s\n", sess.Branch)
-		return nil
+		return resumeSession(sessions[0])
 	}
 
 	// Multiple sessions - show TUI selector
@@ -62,14 +55,27 @@ func runResume(cmd *cobra.Command, args []string) error {
 			return nil
 		}
 
-		if err := chosen.Activate(); err != nil {
-			return fmt.Errorf("failed to resume session: %w", err)
-		}
+		return resumeSession(chosen)
+	}
 
-		fmt.Println("\nSession resumed:")
-		fmt.Printf("  Goal: %s\n", chosen.Task)
-		fmt.Printf("  Branch: %s\n", chosen.Branch)
+	return nil
+}
+
+// resumeSession activates sess, pausing whichever session was active
+func resumeSession(sess *session.Session) error {
+	prev, _ := session.Load()
+
+	if err := sess.Activate(); err != nil {
+		return fmt.Errorf("failed to resume session: %w", err)
 	}
 
+	fmt.Println("\nSession resumed:")
+	fmt.Printf("  Goal: %s\n", sess.Task)
+	fmt.Printf("  Branch: %s\n", sess.Branch)
+
+	if prev != nil && prev.ID != sess.ID {
+		publish(prev, notify.EventPaused, "")
+	}
+	publish(sess, notify.EventResumed, "")
 	return nil
 }
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Review the past week of focus sessions",
+	Long: `Summarizes the sessions you worked on: goals completed vs abandoned,
+planned vs actual time, the biggest overruns, recurring drift categories,
+parked follow-ups still waiting and what you wrote in your reflections.
+
+Example:
+  focus review --week
+  focus review --since 2026-03-01
+  focus review --week --markdown -o retro.md`,
+	Args: cobra.NoArgs,
+	RunE: runReview,
+}
+
+var (
+	reviewWeek     bool
+	reviewSince    string
+	reviewMarkdown bool
+	reviewOutput   string
+)
+
+func init() {
+	reviewCmd.Flags().BoolVar(&reviewWeek, "week", false, "Review the last 7 days (the default)")
+	reviewCmd.Flags().StringVar(&reviewSince, "since", "", "Start of the review: a date (2006-01-02) or how far back (e.g., 14d, 36h)")
+	reviewCmd.Flags().BoolVar(&reviewMarkdown, "markdown", false, "Print the report as Markdown")
+	reviewCmd.Flags().StringVarP(&reviewOutput, "output", "o", "", "Write the Markdown report to a file")
+	reviewCmd.MarkFlagsMutuallyExclusive("week", "since")
+	rootCmd.AddCommand(reviewCmd)
+}
+
+func runReview(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	from := now.AddDate(0, 0, -7)
+	if reviewSince != "" {
+		since, err := parseSince(reviewSince, now)
+		if err != nil {
+			return err
+		}
+		from = since
+	}
+
+	report, err := review.Load(from, now, now)
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+
+	if reviewOutput != "" {
+		if err := os.WriteFile(reviewOutput, []byte(report.Markdown()), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", reviewOutput, err)
+		}
+		fmt.Printf("✓ Review written to %s\n", reviewOutput)
+		return nil
+	}
+	if reviewMarkdown {
+		fmt.Print(report.Markdown())
+		return nil
+	}
+
+	printReview(report)
+	return nil
+}
+
+func printReview(r review.Report) {
+	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("🗓️  Focus Review: %s – %s\n", r.From.Format("Mon Jan 2"), r.To.Format("Mon Jan 2"))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Completed:   %d\n", len(r.Completed))
+	fmt.Printf("Abandoned:   %d\n", len(r.Abandoned))
+	fmt.Printf("In progress: %d\n", len(r.InProgress))
+	if r.Ended() > 0 {
+		fmt.Printf("Time:        %s spent of %s planned (%s)\n", formatDuration(r.Actual), formatDuration(r.Planned), r.Variance())
+	}
+	if avg := r.AverageRating(); avg > 0 {
+		fmt.Printf("Focus:       %.1f/5 on average\n", avg)
+	}
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if r.Ended() == 0 && len(r.InProgress) == 0 {
+		fmt.Println("\nNo sessions in this period. Run 'focus start' to begin")
+	}
+
+	for _, group := range []struct {
+		title     string
+		summaries []review.Summary
+	}{
+		{"✅ Completed", r.Completed},
+		{"🗑️  Abandoned", r.Abandoned},
+		{"📌 In progress", r.InProgress},
+	} {
+		if len(group.summaries) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s:\n", group.title)
+		for _, s := range group.summaries {
+			fmt.Printf("  • %s (%s of %s)\n", s.Session.Task, formatDuration(s.Actual), s.Session.TimeBox)
+		}
+	}
+
+	if len(r.Overruns) > 0 {
+		fmt.Println("\n⏱️  Biggest overruns:")
+		for i, s := range r.Overruns {
+			fmt.Printf("  %d. %s - %s for %s (+%s)\n",
+				i+1, s.Session.Task, formatDuration(s.Actual), s.Session.TimeBox, formatDuration(s.Overrun()))
+		}
+	}
+
+	if len(r.Categories) > 0 {
+		fmt.Println("\n🐰 Drifts:")
+		for _, c := range r.Categories {
+			fmt.Printf("  %-15s %3d drifts in %d sessions, %s lost",
+				c.Category.Label(), c.Drifts, c.Sessions, formatDuration(time.Duration(c.MinutesLost)*time.Minute))
+			if c.Recurring() {
+				fmt.Print("  (recurring)")
+			}
+			fmt.Println()
+		}
+	}
+
+	if len(r.FollowUps) > 0 {
+		fmt.Println("\n📌 Parked follow-ups still waiting:")
+		for _, sess := range r.FollowUps {
+			fmt.Printf("  • %s (parked %s)\n", sess.Task, sess.StartTime.Format("Mon Jan 2"))
+		}
+		fmt.Println("  Pick one up with 'focus resume'")
+	}
+
+	if len(r.Reflections) > 0 {
+		fmt.Println("\n💭 Reflections:")
+		for _, e := range r.Reflections {
+			fmt.Printf("\n  %s (%s)\n", e.Task, e.Reflection.Time.Format("Mon Jan 2"))
+			printReflection(e.Reflection, "    ")
+		}
+	}
+}
+
+// parseSince reads a date (2006-01-02) or how far back to go, in days
+// ("14d") or as a duration ("36h")
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --since %q (want a date like 2006-01-02, or 14d, 36h)", value)
+}
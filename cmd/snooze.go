@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze [duration]",
+	Short: "Silence reminders for a while",
+	Long: `Stop the background watcher from sending reminders for a while.
+
+Example:
+  focus snooze       # 10 minutes
+  focus snooze 30m
+  focus snooze 0     # Resume reminders now`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSnooze,
+}
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+}
+
+func runSnooze(cmd *cobra.Command, args []string) error {
+	sess, err := session.Load()
+	if err != nil {
+		return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
+	}
+
+	duration := 10 * time.Minute
+	if len(args) == 1 {
+		if args[0] == "0" {
+			duration = 0
+		} else if duration, err = time.ParseDuration(args[0]); err != nil {
+			return fmt.Errorf("invalid duration %q (e.g., 10m, 1h)", args[0])
+		}
+	}
+
+	sess.Snooze(duration, time.Now())
+	if err := sess.Save(); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	if sess.SnoozedUntil == nil {
+		record(sess, events.Snoozed, map[string]string{"until": ""})
+		fmt.Println("✓ Reminders resumed")
+	} else {
+		record(sess, events.Snoozed, map[string]string{"until": sess.SnoozedUntil.Format(time.RFC3339)})
+		fmt.Printf("💤 Reminders snoozed until %s\n", sess.SnoozedUntil.Format("15:04"))
+	}
+	return nil
+}
@@ -5,8 +5,15 @@ import (
 	"os/exec"
 	"time"
 
+	"github.com/n3sty/focus/internal/backlog"
+	"github.com/n3sty/focus/internal/calendar"
+	"github.com/n3sty/focus/internal/config"
 	"github.com/n3sty/focus/internal/daemon"
 	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/hooks"
+	"github.com/n3sty/focus/internal/issue"
+	"github.com/n3sty/focus/internal/notify"
+	"github.com/n3sty/focus/internal/pomodoro"
 	"github.com/n3sty/focus/internal/session"
 	"github.com/spf13/cobra"
 )
@@ -22,27 +29,166 @@ This will:
 - Make an empty commit marking the session start
 
 Example:
-  focus start "Fix non-PDF OCR support" --time 3h`,
-	Args: cobra.ExactArgs(1),
+  focus start "Fix non-PDF OCR support" --time 3h
+  focus start "Write migration" --pomodoro 25/5/15x4
+  focus start "Fix OCR crash" --step "repro" --step "fix" --step "test"
+  focus start "Non-PDF OCR" --criterion "Handles PNG and TIFF" --verify "go test ./ocr/..."
+  focus start --from-backlog 3
+  focus start --issue 123
+  focus start "Fix ABC-42 upload timeout"
+  focus start "Refactor parser" --calendar ~/cal/work.ics --fit
+
+With a calendar export (--calendar, or "calendar": {"path": ...} in
+.focus/config.json), focus warns when the timebox runs into a meeting and
+suggests one that ends before it.
+
+An issue given with --issue, or mentioned in the task as #123 or ABC-123,
+goes into the branch name and commit messages. With "issues" set up in
+.focus/config.json its title is fetched, and used as the task if you
+don't give one.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runStart,
 }
 
-var timeBox string
+// defaultTimeBox is used when no --time is given, and for parked drifts
+const defaultTimeBox = "3h"
+
+var (
+	timeBox      string
+	pomodoroPlan string
+	fromBacklog  string
+	steps        []string
+	criteria     []string
+	verify       []string
+	calendarPath string
+	fitTimeBox   bool
+	issueKey     string
+)
 
 func init() {
-	startCmd.Flags().StringVarP(&timeBox, "time", "t", "3h", "Timebox duration (e.g., 1h, 90m, 2h30m)")
+	startCmd.Flags().StringVarP(&timeBox, "time", "t", defaultTimeBox, "Timebox duration (e.g., 1h, 90m, 2h30m)")
+	startCmd.Flags().StringArrayVar(&steps, "step", nil, "Step towards the goal, in order (repeatable)")
+	startCmd.Flags().StringArrayVar(&criteria, "criterion", nil, "Acceptance criterion to confirm at 'focus end' (repeatable)")
+	startCmd.Flags().StringArrayVar(&verify, "verify", nil, "Shell command that must pass before merging (repeatable)")
+	startCmd.Flags().StringVar(&fromBacklog, "from-backlog", "", "Start the backlog item with this ID")
+	startCmd.Flags().StringVar(&issueKey, "issue", "", "Tracker issue this session works on (123, #123 or ABC-123)")
+	startCmd.Flags().StringVar(&calendarPath, "calendar", "", "Calendar export (.ics file or directory) to check for meetings")
+	startCmd.Flags().BoolVar(&fitTimeBox, "fit", false, "Shorten the timebox to end before the next meeting")
+	startCmd.Flags().StringVar(&pomodoroPlan, "pomodoro", "", "Pomodoro cycle in minutes: work/short/longxrounds (e.g., 25/5/15x4)")
 	rootCmd.AddCommand(startCmd)
 }
 
 func runStart(cmd *cobra.Command, args []string) error {
+	// Promote a backlog item: its title, estimate, notes and links carry over
+	var item *backlog.Item
+	if fromBacklog != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("give either a task or --from-backlog, not both")
+		}
+		id, err := parseBacklogID(fromBacklog)
+		if err != nil {
+			return err
+		}
+		b, err := backlog.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load backlog: %w", err)
+		}
+		if item, err = b.Get(id); err != nil {
+			return err
+		}
+		if item.Estimate != "" && !cmd.Flags().Changed("time") {
+			timeBox = item.Estimate
+		}
+		args = []string{item.Title}
+	}
+	// Link the session to a tracker issue
+	var iss *issue.Issue
+	if issueKey != "" {
+		key, err := issue.ParseKey(issueKey)
+		if err != nil {
+			return err
+		}
+		iss = &issue.Issue{Key: key}
+	} else if len(args) > 0 {
+		// A broken config is reported by lookupIssue
+		var projects []string
+		if settings, err := config.Load(); err == nil {
+			projects = settings.Issues.Projects
+		}
+		if key, ok := issue.FromTask(args[0], projects); ok {
+			iss = &issue.Issue{Key: key}
+		}
+	}
+	if iss != nil {
+		lookupIssue(iss)
+		if len(args) == 0 && iss.Title != "" {
+			args = []string{iss.Title}
+		}
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("what do you want to focus on? Run 'focus start \"task\"', 'focus start --issue <id>' or 'focus start --from-backlog <id>'")
+	}
+
 	task := args[0]
 
+	// Look for meetings the timebox would run into
+	if fitted, ok := checkCalendar(timeBox, time.Now()); ok && fitTimeBox {
+		timeBox = fitted
+	}
+
+	// Validate the pomodoro plan before touching git
+	var pomo *pomodoro.State
+	if cmd.Flags().Changed("pomodoro") {
+		plan, err := pomodoro.Parse(pomodoroPlan)
+		if err != nil {
+			return err
+		}
+		pomo = pomodoro.New(plan, time.Now())
+	}
+
+	// Describe the session up front so pre-start hooks can inspect it
+	sess := &session.Session{
+		ID:        session.GenerateID(task),
+		Task:      task,
+		StartTime: time.Now(),
+		TimeBox:   timeBox,
+		Drifts:    []session.Drift{},
+		Status:    "active",
+		Pomodoro:  pomo,
+		Issue:     iss,
+	}
+	for _, step := range steps {
+		sess.AddStep(step)
+	}
+	for _, c := range criteria {
+		sess.Criteria = append(sess.Criteria, session.Criterion{Description: c})
+	}
+	for _, command := range verify {
+		sess.Criteria = append(sess.Criteria, session.Criterion{Description: command, Command: command})
+	}
+	if item != nil {
+		for _, note := range item.Notes {
+			sess.Notes = append(sess.Notes, session.Note{Time: item.Created, Text: note})
+		}
+		sess.Links = item.Links
+	}
+
+	// Let pre-start hooks veto before anything changes
+	if err := runHook(hooks.PreStart, sess, nil); err != nil {
+		return err
+	}
+
 	// If active session exists, pause it
 	if session.Exists() {
+		prev, _ := session.Load()
 		if err := session.PauseActive(); err != nil {
 			return fmt.Errorf("failed to pause current session: %w", err)
 		}
 		fmt.Println("✓ Paused current session")
+		if prev != nil {
+			publish(prev, notify.EventPaused, "")
+		}
 	}
 
 	// Check if in a git repository
@@ -54,28 +200,34 @@ func runStart(cmd *cobra.Command, args []string) error {
 	fmt.Printf("🎯 Starting focus session: %s\n", task)
 	fmt.Printf("⏱️  Timebox: %s\n\n", timeBox)
 
-	branch, err := git.CreateFocusBranch(task)
+	ref := ""
+	if iss != nil {
+		ref = iss.Ref()
+		fmt.Printf("🔗 Issue: %s\n", issueLine(iss))
+	}
+
+	branch, err := git.CreateFocusBranch(task, ref)
 	if err != nil {
 		return fmt.Errorf("failed to create git branch: %w", err)
 	}
 
 	fmt.Printf("✓ Created branch: %s\n", branch)
 
-	// Create session
-	sess := &session.Session{
-		ID:        session.GenerateID(task),
-		Task:      task,
-		StartTime: time.Now(),
-		TimeBox:   timeBox,
-		Branch:    branch,
-		Drifts:    []session.Drift{},
-		Status:    "active",
-	}
+	sess.Branch = branch
+	sess.StartTime = time.Now()
 
 	if err := sess.Save(); err != nil {
 		return fmt.Errorf("failed to save session: %w", err)
 	}
 
+	if item != nil {
+		if err := removeFromBacklog(item.ID); err != nil {
+			fmt.Printf("⚠️  Warning: could not remove #%d from the backlog: %v\n", item.ID, err)
+		} else {
+			fmt.Printf("✓ Promoted backlog item #%d\n", item.ID)
+		}
+	}
+
 	// Start background watcher if not already running
 	if daemon.IsRunning() {
 		fmt.Println("✓ Watcher already running")
@@ -90,10 +242,15 @@ func runStart(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Println("✓ Session saved")
+	publish(sess, notify.EventStarted, "")
+	runHook(hooks.PostStart, sess, nil)
 	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("🎯 Focus Session Active\n")
 	fmt.Printf("   Goal: %s\n", task)
 	fmt.Printf("   Time: %s\n", timeBox)
+	if pomo != nil {
+		fmt.Printf("   Pomodoro: %s\n", pomo.Plan)
+	}
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println("\nUse these commands during your session:")
 	fmt.Println("  focus check  - Check if you're still on track")
@@ -102,3 +259,116 @@ func runStart(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// removeFromBacklog drops an item once it has become a session
+func removeFromBacklog(id int) error {
+	b, err := backlog.Load()
+	if err != nil {
+		return err
+	}
+	if err := b.Remove(id); err != nil {
+		return err
+	}
+	return b.Save()
+}
+
+// meetingBuffer is left between a suggested timebox and the meeting, to
+// wrap up and get there
+const meetingBuffer = 5 * time.Minute
+
+// checkCalendar warns about meetings between now and the end of the
+// timebox. It returns a shorter timebox that ends before the first one, if
+// one fits.
+func checkCalendar(timebox string, now time.Time) (string, bool) {
+	path := calendarPath
+	if path == "" {
+		settings, err := config.Load()
+		if err != nil {
+			fmt.Printf("⚠️  Warning: %v\n", err)
+			return "", false
+		}
+		path = settings.Calendar.Path
+	}
+	if path == "" {
+		return "", false
+	}
+
+	length, err := time.ParseDuration(timebox)
+	if err != nil {
+		return "", false
+	}
+
+	evs, err := calendar.Load(path, now, now.Add(length))
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not read calendar: %v\n", err)
+		return "", false
+	}
+
+	meetings := calendar.Between(evs, now, now.Add(length))
+	if len(meetings) == 0 {
+		return "", false
+	}
+
+	fmt.Printf("📅 Your %s timebox runs into:\n", timebox)
+	for _, m := range meetings {
+		fmt.Printf("   • %s-%s %s\n", m.Start.Format("15:04"), m.End.Format("15:04"), m.Summary)
+	}
+
+	first := meetings[0]
+	if !first.Start.After(now) {
+		fmt.Printf("⚠️  '%s' is on right now (until %s)\n\n", first.Summary, first.End.Format("15:04"))
+		return "", false
+	}
+
+	fits := (first.Start.Sub(now) - meetingBuffer).Truncate(5 * time.Minute)
+	if fits < 15*time.Minute {
+		fmt.Printf("⚠️  '%s' starts at %s - not enough time for a session before it\n\n", first.Summary, first.Start.Format("15:04"))
+		return "", false
+	}
+
+	fitted := session.FormatTimebox(fits)
+	if fitTimeBox {
+		fmt.Printf("✓ Timebox shortened to %s to finish before %s\n\n", fitted, first.Start.Format("15:04"))
+	} else {
+		fmt.Printf("💡 Use --time %s (or --fit) to finish before %s\n\n", fitted, first.Start.Format("15:04"))
+	}
+	return fitted, true
+}
+
+// lookupIssue fills in the issue's title and URL from the configured
+// tracker. Problems are reported but never stop the session.
+func lookupIssue(iss *issue.Issue) {
+	settings, err := config.Load()
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+		return
+	}
+
+	provider, err := issue.FromConfig(settings.Issues)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+		return
+	}
+	if provider == nil {
+		return
+	}
+
+	fetched, err := provider.Fetch(iss.Key)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not fetch %s: %v\n", iss.Ref(), err)
+		return
+	}
+	*iss = *fetched
+}
+
+// issueLine describes an issue as "#123 Title (url)"
+func issueLine(iss *issue.Issue) string {
+	line := iss.Ref()
+	if iss.Title != "" {
+		line += " " + iss.Title
+	}
+	if iss.URL != "" {
+		line += fmt.Sprintf(" (%s)", iss.URL)
+	}
+	return line
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/session"
+	"github.com/n3sty/focus/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show which kinds of drift cost you the most",
+	Long: `Summarizes drifts and check-ins across all your sessions, including ones
+that were merged or discarded: time lost per drift category, severity,
+the most common tags, and how often you answered or deferred checks.`,
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	report, err := stats.Load()
+	if err != nil {
+		return fmt.Errorf("failed to read event logs: %w", err)
+	}
+
+	if report.Sessions == 0 {
+		fmt.Println("No sessions recorded yet. Run 'focus start' to begin")
+		return nil
+	}
+
+	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("📈 Focus Stats")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Sessions: %d\n", report.Sessions)
+	fmt.Printf("Drifts:   %d\n", report.Drifts)
+	fmt.Printf("Lost:     %s (estimated)\n", formatDuration(time.Duration(report.MinutesLost)*time.Minute))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if len(report.Categories) > 0 {
+		fmt.Println("\n🐰 Costliest rabbit holes:")
+		for i, c := range report.Categories {
+			fmt.Printf("  %d. %-15s %3d drifts  %7s lost",
+				i+1, c.Category.Label(), c.Drifts, formatDuration(time.Duration(c.MinutesLost)*time.Minute))
+			if c.High > 0 {
+				fmt.Printf("  (%d high severity)", c.High)
+			}
+			fmt.Println()
+		}
+	}
+
+	if len(report.Severities) > 0 {
+		fmt.Printf("\nSeverity: %d low, %d medium, %d high\n",
+			report.Severities[session.SeverityLow],
+			report.Severities[session.SeverityMedium],
+			report.Severities[session.SeverityHigh])
+	}
+
+	if len(report.Tags) > 0 {
+		var tags []string
+		for _, t := range report.Tags[:min(len(report.Tags), 10)] {
+			tags = append(tags, fmt.Sprintf("#%s (%d)", t.Tag, t.Count))
+		}
+		fmt.Printf("\n🏷️  Top tags: %s\n", strings.Join(tags, ", "))
+	}
+
+	checks := report.CheckIns
+	if total := checks["yes"] + checks["no"] + checks["defer"] + checks["switch"]; total > 0 {
+		fmt.Printf("\n✅ Check-ins: %d (%d on track, %d drifted, %d deferred = %.0f%%)\n",
+			total, checks["yes"]+checks["switch"], checks["no"], checks["defer"],
+			float64(checks["defer"])/float64(total)*100)
+	}
+
+	return nil
+}
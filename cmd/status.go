@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/n3sty/focus/internal/events"
 	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/hooks"
 	"github.com/n3sty/focus/internal/session"
 	"github.com/spf13/cobra"
 )
@@ -28,28 +30,80 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get commit count
-	commits, err := git.GetCommitsSince(sess.StartTime)
+	commits, err := git.GetCommitsSince(sess.Branch, sess.StartTime)
 	if err != nil {
 		commits = 0 // Non-fatal, just show 0
 	}
 
-	// Calculate elapsed time
-	elapsed := time.Since(sess.StartTime)
+	// Calculate elapsed and focused time
+	now := time.Now()
+	elapsed := now.Sub(sess.StartTime)
 	elapsedStr := formatDuration(elapsed)
+	focused := sess.FocusedTime(now)
 
 	// Display status
 	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println("🎯 Focus Session Status")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("Goal:     %s\n", sess.Task)
+	if sess.Issue != nil {
+		fmt.Printf("Issue:    %s\n", issueLine(sess.Issue))
+	}
 	fmt.Printf("Started:  %s\n", sess.StartTime.Format("15:04 PM"))
 	fmt.Printf("Elapsed:  %s\n", elapsedStr)
+	if len(sess.Pauses) > 0 {
+		fmt.Printf("Focused:  %s\n", formatDuration(focused))
+	}
 	fmt.Printf("Timebox:  %s\n", sess.TimeBox)
 	fmt.Printf("Branch:   %s\n", sess.Branch)
 	fmt.Printf("Commits:  %d\n", commits)
 	fmt.Printf("Drifts:   %d\n", len(sess.Drifts))
+	if checks := sess.CheckInSummary(); checks.Total > 0 {
+		fmt.Printf("Checks:   %d (%d on track, %d drifted, %d deferred)\n",
+			checks.Total, checks.OnTrack, checks.Drifted, checks.Deferred)
+		if checks.Prompts > 0 {
+			fmt.Printf("Answered: %.0f%% of %d prompts", checks.Compliance()*100, checks.Prompts)
+			if checks.Prompted > 0 {
+				fmt.Printf(", after %s on average", formatLatency(checks.AvgLatency))
+			}
+			fmt.Println()
+		}
+	}
+	if pomo := sess.Pomodoro; pomo != nil {
+		icon := "🍅"
+		if pomo.Phase.IsBreak() {
+			icon = "☕"
+		}
+		fmt.Printf("Pomodoro: %s %s - %s left (%d done, plan %s)\n",
+			icon, pomo.Phase.Label(), formatCountdown(pomo.Remaining(now)), pomo.Completed, pomo.Plan)
+	}
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
+	if sess.SnoozedUntil != nil && now.Before(*sess.SnoozedUntil) {
+		fmt.Printf("\n🔕 Reminders snoozed until %s\n", sess.SnoozedUntil.Format("15:04"))
+	}
+
+	if meeting := sess.OpenPause(); meeting != nil && meeting.Reason == "meeting" {
+		fmt.Printf("\n📅 In a meeting since %s - focused time is paused\n", meeting.Start.Format("15:04"))
+	} else if paused := sess.OpenPause(); paused != nil && paused.Reason == "paused" {
+		fmt.Printf("\n⏸  Paused since %s - run 'focus resume' to continue\n", paused.Start.Format("15:04"))
+	} else if idle := sess.OpenPause(); idle != nil {
+		fmt.Printf("\n💤 Idle since %s - focused time is paused\n", idle.Start.Format("15:04"))
+	} else if sess.UnreviewedIdle() != nil {
+		fmt.Println("\n💤 Idle time to review - run 'focus check' to mark it as a break or work")
+	}
+
+	// Status only reads the session; the watcher and 'focus check' log
+	// the switch
+	if sess.OffBranch != nil {
+		fmt.Printf("\n⚠️  You're on '%s', not your focus branch (for %s)\n",
+			sess.OffBranch.Branch, formatDuration(time.Since(sess.OffBranch.Since)))
+		fmt.Printf("   Run 'git switch %s' or 'focus check' to get back\n", sess.Branch)
+	} else if current, _ := git.GetCurrentBranch(); current != "" && current != sess.Branch {
+		fmt.Printf("\n⚠️  You're on '%s', not your focus branch\n", current)
+		fmt.Printf("   Run 'git switch %s' or 'focus check' to get back\n", sess.Branch)
+	}
+
 	// Show drifts if any
 	if len(sess.Drifts) > 0 {
 		fmt.Println("\n🐰 Drift Log:")
@@ -59,10 +113,42 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			if drift.Reason != "" {
 				fmt.Printf(" (Reason: %s)", drift.Reason)
 			}
+			if details := drift.Details(); details != "" {
+				fmt.Printf(" - %s", details)
+			}
 			fmt.Println()
 		}
 	}
 
+	if len(sess.Steps) > 0 {
+		printSteps(sess)
+	}
+
+	if len(sess.Criteria) > 0 {
+		fmt.Println("\n✅ Definition of done:")
+		for _, c := range sess.Criteria {
+			if c.Command != "" {
+				fmt.Printf("  • %s (checked by 'focus end')\n", c.Command)
+			} else {
+				fmt.Printf("  • %s\n", c.Description)
+			}
+		}
+	}
+
+	if len(sess.Notes) > 0 {
+		fmt.Println("\n📝 Notes:")
+		for _, note := range sess.Notes {
+			fmt.Printf("  • %s\n", note.Text)
+		}
+	}
+
+	if len(sess.Links) > 0 {
+		fmt.Println("\n🔗 Links:")
+		for _, link := range sess.Links {
+			fmt.Printf("  %s\n", link)
+		}
+	}
+
 	fmt.Println("\nCommands:")
 	fmt.Println("  focus check - Verify you're still on track")
 	fmt.Println("  focus end   - Complete or abandon this session")
@@ -70,6 +156,55 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// trackBranch compares HEAD with the focus branch, saving and logging any
+// change the watcher hasn't picked up yet. Returning runs on-drift hooks
+// just like the watcher does.
+func trackBranch(sess *session.Session) error {
+	current, _ := git.GetCurrentBranch()
+	off := sess.OffBranch
+
+	switch sess.TrackBranch(current, time.Now()) {
+	case session.BranchLeft:
+		record(sess, events.BranchLeft, map[string]string{"branch": current})
+	case session.BranchReturned:
+		drift := sess.Drifts[len(sess.Drifts)-1]
+		record(sess, events.BranchReturned, map[string]string{
+			"branch":   off.Branch,
+			"duration": time.Since(off.Since).Round(time.Second).String(),
+		})
+		record(sess, events.DriftLogged, drift.Fields())
+		defer driftHook(sess, drift)
+	default:
+		return nil
+	}
+
+	return sess.Save()
+}
+
+// driftHook runs on-drift hooks for a logged drift
+func driftHook(sess *session.Session, drift session.Drift) {
+	runHook(hooks.OnDrift, sess, map[string]string{
+		"FOCUS_DRIFT":          drift.Description,
+		"FOCUS_DRIFT_REASON":   drift.Reason,
+		"FOCUS_DRIFT_CATEGORY": string(drift.Category),
+		"FOCUS_DRIFT_SEVERITY": string(drift.Severity),
+	})
+}
+
+// formatCountdown renders a duration as mm:ss
+func formatCountdown(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// formatLatency renders short waits in seconds and longer ones in minutes
+func formatLatency(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	return formatDuration(d)
+}
+
 func formatDuration(d time.Duration) string {
 	h := int(d.Hours())
 	m := int(d.Minutes()) % 60
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var stepCmd = &cobra.Command{
+	Use:   "step",
+	Short: "Show the current session's checklist",
+	Long: `Shows the steps of the current session. Add steps at start with
+'focus start "task" --step "repro" --step "fix"', or later with 'focus step add'.`,
+	Args: cobra.NoArgs,
+	RunE: runStep,
+}
+
+var stepAddCmd = &cobra.Command{
+	Use:   "add <title>",
+	Short: "Add a step to the end of the checklist",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runStepAdd,
+}
+
+var stepDoneCmd = &cobra.Command{
+	Use:   "done [number]",
+	Short: "Check off a step (the current one by default)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runStepDone,
+}
+
+func init() {
+	stepCmd.AddCommand(stepAddCmd)
+	stepCmd.AddCommand(stepDoneCmd)
+	rootCmd.AddCommand(stepCmd)
+}
+
+func runStep(cmd *cobra.Command, args []string) error {
+	sess, err := session.Load()
+	if err != nil {
+		return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
+	}
+
+	if len(sess.Steps) == 0 {
+		fmt.Println("No steps yet. Add one with 'focus step add \"title\"'")
+		return nil
+	}
+
+	printSteps(sess)
+	return nil
+}
+
+func runStepAdd(cmd *cobra.Command, args []string) error {
+	sess, err := session.Load()
+	if err != nil {
+		return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
+	}
+
+	sess.AddStep(args[0])
+	if err := sess.Save(); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	recordStep(sess, events.StepAdded, len(sess.Steps)-1)
+
+	fmt.Printf("✓ Added step %d: %s\n", len(sess.Steps), args[0])
+	return nil
+}
+
+func runStepDone(cmd *cobra.Command, args []string) error {
+	sess, err := session.Load()
+	if err != nil {
+		return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
+	}
+
+	step := sess.CurrentStep()
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid step number %q", args[0])
+		}
+		step = n - 1
+	}
+	if step < 0 {
+		return fmt.Errorf("no open steps left")
+	}
+
+	if err := sess.CompleteStep(step, time.Now()); err != nil {
+		return err
+	}
+	if err := sess.Save(); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	recordStep(sess, events.StepDone, step)
+
+	fmt.Printf("✓ Done: %s\n", sess.Steps[step].Title)
+	if next := sess.CurrentStep(); next >= 0 {
+		fmt.Printf("➡️  Next: %s\n", sess.Steps[next].Title)
+	} else {
+		fmt.Println("🎉 All steps done! Run 'focus end' to wrap up")
+	}
+	return nil
+}
+
+// printSteps lists the checklist with the current step highlighted
+func printSteps(sess *session.Session) {
+	done, total := sess.StepProgress()
+	current := sess.CurrentStep()
+
+	fmt.Printf("\n📋 Steps (%d/%d, %d%%):\n", done, total, done*100/total)
+	for i, step := range sess.Steps {
+		mark := "○"
+		switch {
+		case step.Done():
+			mark = "✓"
+		case i == current:
+			mark = "▸"
+		}
+		fmt.Printf("  %s %d. %s\n", mark, i+1, step.Title)
+	}
+}
+
+// recordStep logs a change to step i (0-based)
+func recordStep(sess *session.Session, t events.Type, i int) {
+	record(sess, t, map[string]string{"step": strconv.Itoa(i + 1), "title": sess.Steps[i].Title})
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/session"
+	"github.com/n3sty/focus/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline [session-id]",
+	Short: "Show a timeline of the current session",
+	Long: `Draws a timeline of a focus session: focused and paused time, check-ins,
+commits, drifts and branch switches, with a scrollable list of details.
+
+Shows the active session unless a session ID is given.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTimeline,
+}
+
+func init() {
+	rootCmd.AddCommand(timelineCmd)
+}
+
+func runTimeline(cmd *cobra.Command, args []string) error {
+	var (
+		sess *session.Session
+		err  error
+	)
+	if len(args) == 1 {
+		sess, err = session.LoadByID(args[0])
+		if err != nil {
+			sess, err = session.LoadArchived(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("❌ Session %s not found", args[0])
+		}
+	} else {
+		sess, err = session.Load()
+		if err != nil {
+			return fmt.Errorf("❌ No active focus session. Run 'focus start' to begin")
+		}
+	}
+
+	log, err := events.Load(sess.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read event log: %w", err)
+	}
+
+	commits, err := git.GetCommits(sess.Branch, sess.StartTime)
+	if err != nil {
+		commits = nil // Non-fatal, the branch may be gone
+	}
+
+	// Ended sessions are drawn up to when they ended
+	end := time.Now()
+	if sess.EndTime != nil {
+		end = *sess.EndTime
+	}
+
+	model := tui.NewTimelineModel(sess, log, commits, end)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running TUI: %w", err)
+	}
+
+	return nil
+}
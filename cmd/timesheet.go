@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/config"
+	"github.com/n3sty/focus/internal/export"
+	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/timesheet"
+	"github.com/n3sty/focus/internal/worklog"
+	"github.com/spf13/cobra"
+)
+
+var timesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Turn focused time into timesheet entries",
+	Long: `Lists the time you spent focused as timesheet entries: one per focused
+interval, with the repository as project, the task as description and drift
+categories and tags as tags. Idle time, breaks and meetings are left out.
+Covers the last 7 days, or this week from Monday with --week.
+
+Set the project, client and billable flag in .focus/config.json under
+"timesheet". Export for Toggl or Clockify, or push to a JSON API:
+
+Example:
+  focus timesheet --week
+  focus timesheet --week --format toggl -o toggl.csv
+  focus timesheet --since 2026-03-01 --format clockify > clockify.csv
+  focus timesheet --week --push`,
+	Args: cobra.NoArgs,
+	RunE: runTimesheet,
+}
+
+var (
+	timesheetWeek   bool
+	timesheetSince  string
+	timesheetFormat string
+	timesheetOutput string
+	timesheetPush   bool
+)
+
+func init() {
+	timesheetCmd.Flags().BoolVar(&timesheetWeek, "week", false, "Cover this calendar week, from Monday (default: the last 7 days)")
+	timesheetCmd.Flags().StringVar(&timesheetSince, "since", "", "Start of the timesheet: a date (2006-01-02) or how far back (e.g., 14d, 36h)")
+	timesheetCmd.Flags().StringVarP(&timesheetFormat, "format", "f", "", "Export as toggl, clockify or json instead of a table")
+	timesheetCmd.Flags().StringVarP(&timesheetOutput, "output", "o", "", "Write the export to a file instead of stdout")
+	timesheetCmd.Flags().BoolVar(&timesheetPush, "push", false, "Send the entries to timesheet.api in .focus/config.json")
+	timesheetCmd.MarkFlagsMutuallyExclusive("week", "since")
+	timesheetCmd.MarkFlagsMutuallyExclusive("format", "push")
+	rootCmd.AddCommand(timesheetCmd)
+}
+
+func runTimesheet(cmd *cobra.Command, args []string) error {
+	settings, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -7)
+	switch {
+	case timesheetWeek:
+		from = timesheet.WeekStart(now)
+	case timesheetSince != "":
+		if from, err = parseSince(timesheetSince, now); err != nil {
+			return err
+		}
+	}
+
+	entries, err := worklog.LoadAll(now)
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+
+	opts := timesheet.Options{
+		Project:  settings.Timesheet.Project,
+		Client:   settings.Timesheet.Client,
+		Billable: settings.Timesheet.Billable,
+		Email:    git.GetUserEmail(),
+	}
+	if opts.Project == "" {
+		opts.Project = git.RepoName()
+	}
+	sheet := timesheet.Build(export.Between(entries, from, now), opts)
+
+	if timesheetPush {
+		if settings.Timesheet.API == nil {
+			return fmt.Errorf("no timesheet API configured. Add \"timesheet\": {\"api\": {\"url\": ...}} to .focus/config.json")
+		}
+		if err := timesheet.Push(*settings.Timesheet.API, sheet); err != nil {
+			return fmt.Errorf("failed to push timesheet: %w", err)
+		}
+		fmt.Printf("✓ Pushed %d entries to %s\n", len(sheet), settings.Timesheet.API.URL)
+		return nil
+	}
+
+	if timesheetFormat == "" {
+		printTimesheet(sheet, from, now)
+		return nil
+	}
+
+	write, err := timesheetWriter(timesheetFormat)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if timesheetOutput != "" {
+		f, err := os.Create(timesheetOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", timesheetOutput, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := write(out, sheet); err != nil {
+		return fmt.Errorf("failed to export timesheet: %w", err)
+	}
+	if timesheetOutput != "" {
+		fmt.Printf("✓ Exported %d entries to %s\n", len(sheet), timesheetOutput)
+	}
+	return nil
+}
+
+// timesheetWriter picks the exporter for a --format value
+func timesheetWriter(format string) (func(io.Writer, []timesheet.Entry) error, error) {
+	switch strings.ToLower(format) {
+	case "toggl":
+		return timesheet.TogglCSV, nil
+	case "clockify":
+		return timesheet.ClockifyCSV, nil
+	case "json":
+		return timesheet.JSON, nil
+	}
+	return nil, fmt.Errorf("invalid format %q (want toggl, clockify or json)", format)
+}
+
+// printTimesheet shows the entries grouped by day with daily and project
+// totals
+func printTimesheet(sheet []timesheet.Entry, from, to time.Time) {
+	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("🧾 Timesheet: %s – %s\n", from.Format("Mon Jan 2"), to.Format("Mon Jan 2"))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if len(sheet) == 0 {
+		fmt.Println("No focused time in this period")
+		return
+	}
+
+	var (
+		total    time.Duration
+		day      string
+		dayTotal time.Duration
+		projects = map[string]time.Duration{}
+		order    []string
+	)
+	endDay := func() {
+		if day != "" {
+			fmt.Printf("  %11s %7s\n", "total", formatDuration(dayTotal))
+		}
+	}
+
+	for _, e := range sheet {
+		if d := e.Start.Format("Mon Jan 2"); d != day {
+			endDay()
+			day, dayTotal = d, 0
+			fmt.Printf("\n%s\n", day)
+		}
+
+		line := fmt.Sprintf("  %s-%s %7s  %s", e.Start.Format("15:04"), e.End.Format("15:04"), formatDuration(e.Duration()), e.Description)
+		if len(e.Tags) > 0 {
+			line += " #" + strings.Join(e.Tags, " #")
+		}
+		fmt.Println(line)
+
+		dayTotal += e.Duration()
+		total += e.Duration()
+		if _, ok := projects[e.Project]; !ok {
+			order = append(order, e.Project)
+		}
+		projects[e.Project] += e.Duration()
+	}
+	endDay()
+
+	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, project := range order {
+		name := project
+		if name == "" {
+			name = "(no project)"
+		}
+		fmt.Printf("%-24s %s\n", name, formatDuration(projects[project]))
+	}
+	fmt.Printf("%-24s %s\n", "Total", formatDuration(total))
+}
@@ -26,6 +26,9 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Start watching
-	cfg := watcher.DefaultConfig()
+	cfg, err := watcher.LoadConfig()
+	if err != nil {
+		return err
+	}
 	return watcher.Watch(cfg)
 }
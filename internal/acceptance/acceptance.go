@@ -0,0 +1,70 @@
+package acceptance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/session"
+)
+
+// timeout stops a hung test suite from blocking 'focus end' forever
+const timeout = 10 * time.Minute
+
+// waitDelay is how long Run waits for output after the command is killed
+// or exits, in case something it started still holds the pipes open
+const waitDelay = 5 * time.Second
+
+// outputLines is how much of a command's output is kept with the result
+const outputLines = 20
+
+// Run executes a criterion's command through the shell. A zero exit
+// status means the criterion passed. Cancelling ctx kills the command and
+// everything it started.
+func Run(ctx context.Context, command string) session.CriterionResult {
+	return run(ctx, command, timeout)
+}
+
+func run(ctx context.Context, command string, timeout time.Duration) session.CriterionResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.WaitDelay = waitDelay
+	ownGroup(cmd)
+
+	start := time.Now()
+	err := cmd.Run()
+	result := session.CriterionResult{
+		Passed:   err == nil,
+		Output:   tail(output.String(), outputLines),
+		RanAt:    start,
+		Duration: time.Since(start),
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.Output = strings.TrimSpace(result.Output + fmt.Sprintf("\ntimed out after %s", timeout))
+	} else if ctx.Err() != nil {
+		result.Output = strings.TrimSpace(result.Output + "\ncancelled")
+	} else if err != nil && result.Output == "" {
+		result.Output = err.Error()
+	}
+
+	return result
+}
+
+// tail keeps the last n lines of s
+func tail(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,75 @@
+package acceptance
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		passed  bool
+		output  string
+	}{
+		{"passes", "echo ok", true, "ok"},
+		{"fails", "echo broken >&2; exit 3", false, "broken"},
+		{"missing command", "definitely-not-a-command-xyz", false, "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Run(context.Background(), tt.command)
+			if r.Passed != tt.passed || !strings.Contains(r.Output, tt.output) {
+				t.Errorf("Run(%q) = passed %v, output %q; want %v containing %q",
+					tt.command, r.Passed, r.Output, tt.passed, tt.output)
+			}
+		})
+	}
+}
+
+func TestRunTimeoutKillsBackgroundJobs(t *testing.T) {
+	// The background sleep inherits the output pipe; killing only the
+	// shell would leave Run waiting for it
+	start := time.Now()
+	r := run(context.Background(), "sleep 30 & echo started; sleep 30", 200*time.Millisecond)
+
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("Run took %s after a 200ms timeout", elapsed)
+	}
+	if r.Passed {
+		t.Error("timed out command passed")
+	}
+	if !strings.Contains(r.Output, "started") || !strings.Contains(r.Output, "timed out") {
+		t.Errorf("output = %q, want the command's output and a timeout note", r.Output)
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	start := time.Now()
+	r := Run(ctx, "sleep 30 & echo started; sleep 30")
+
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("Run took %s after being cancelled", elapsed)
+	}
+	if r.Passed {
+		t.Error("cancelled command passed")
+	}
+	if !strings.Contains(r.Output, "started") || !strings.Contains(r.Output, "cancelled") {
+		t.Errorf("output = %q, want the command's output and a cancel note", r.Output)
+	}
+}
+
+func TestTail(t *testing.T) {
+	if got := tail("a\nb\nc\nd\n", 2); got != "c\nd" {
+		t.Errorf("tail = %q, want %q", got, "c\nd")
+	}
+	if got := tail("one", 5); got != "one" {
+		t.Errorf("tail = %q, want %q", got, "one")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package acceptance
+
+import "os/exec"
+
+// ownGroup leaves cmd alone where process groups aren't available;
+// WaitDelay still stops Run waiting on leftover children
+func ownGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package acceptance
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// ownGroup starts cmd in a process group of its own and makes cancelling
+// it kill the whole group, so background jobs and test binaries the shell
+// started don't outlive the timeout
+func ownGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,82 @@
+package activity
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const markerFile = ".focus/activity"
+
+// maxFiles caps how many files a single scan looks at in large repos
+const maxFiles = 20000
+
+// Touch records terminal activity. It is called from the optional shell
+// hook and does nothing outside a focus project.
+func Touch() error {
+	if _, err := os.Stat(filepath.Dir(markerFile)); err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if err := os.WriteFile(markerFile, []byte(now.Format(time.RFC3339)), 0644); err != nil {
+		return err
+	}
+	return os.Chtimes(markerFile, now, now)
+}
+
+// Last returns the most recent sign of activity in the repository at root:
+// the shell hook marker, the git index, or any modified working tree file
+func Last(root string) time.Time {
+	var latest time.Time
+
+	consider := func(path string) {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	consider(filepath.Join(root, markerFile))
+	consider(filepath.Join(root, ".git", "index"))
+
+	if t := lastModified(root); t.After(latest) {
+		latest = t
+	}
+
+	return latest
+}
+
+// lastModified walks the working tree and returns the newest file mtime,
+// skipping hidden directories and common dependency folders
+func lastModified(root string) time.Time {
+	var latest time.Time
+	seen := 0
+
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		seen++
+		if seen > maxFiles {
+			return filepath.SkipAll
+		}
+
+		if info, err := d.Info(); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+
+	return latest
+}
@@ -0,0 +1,149 @@
+package backlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const backlogFile = ".focus/backlog.json"
+
+// Priority orders backlog items; lower sorts first
+type Priority int
+
+const (
+	PriorityHigh Priority = iota + 1
+	PriorityMedium
+	PriorityLow
+)
+
+var priorityNames = map[Priority]string{
+	PriorityHigh:   "high",
+	PriorityMedium: "medium",
+	PriorityLow:    "low",
+}
+
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return "medium"
+}
+
+// ParsePriority reads "high", "medium" or "low" (or h/m/l)
+func ParsePriority(value string) (Priority, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for p, name := range priorityNames {
+		if value == name || value == name[:1] {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid priority %q (want high, medium or low)", value)
+}
+
+// Item is an idea waiting to become a focus session
+type Item struct {
+	ID       int       `json:"id"`
+	Title    string    `json:"title"`
+	Notes    []string  `json:"notes,omitempty"`
+	Estimate string    `json:"estimate,omitempty"` // Timebox to use when started, e.g. "90m"
+	Priority Priority  `json:"priority"`
+	Links    []string  `json:"links,omitempty"`
+	Created  time.Time `json:"created"`
+	From     string    `json:"from,omitempty"` // Session that was active when it was captured
+}
+
+// Backlog is the list of items in .focus/backlog.json
+type Backlog struct {
+	NextID int    `json:"next_id"`
+	Items  []Item `json:"items"`
+}
+
+// Load reads the backlog, returning an empty one if none exists
+func Load() (*Backlog, error) {
+	b := &Backlog{NextID: 1}
+
+	data, err := os.ReadFile(backlogFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return b, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", backlogFile, err)
+	}
+
+	return b, nil
+}
+
+// Save writes the backlog to disk
+func (b *Backlog) Save() error {
+	if err := os.MkdirAll(filepath.Dir(backlogFile), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(backlogFile, data, 0644)
+}
+
+// Add captures a new item and returns it
+func (b *Backlog) Add(item Item) *Item {
+	if b.NextID < 1 {
+		b.NextID = 1
+	}
+	item.ID = b.NextID
+	b.NextID++
+
+	if item.Priority == 0 {
+		item.Priority = PriorityMedium
+	}
+	if item.Created.IsZero() {
+		item.Created = time.Now()
+	}
+
+	b.Items = append(b.Items, item)
+	return &b.Items[len(b.Items)-1]
+}
+
+// Get finds an item by ID
+func (b *Backlog) Get(id int) (*Item, error) {
+	for i := range b.Items {
+		if b.Items[i].ID == id {
+			return &b.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no backlog item #%d", id)
+}
+
+// Remove deletes an item by ID
+func (b *Backlog) Remove(id int) error {
+	for i := range b.Items {
+		if b.Items[i].ID == id {
+			b.Items = append(b.Items[:i], b.Items[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no backlog item #%d", id)
+}
+
+// Sorted returns the items by priority, oldest first within a priority
+func (b *Backlog) Sorted() []Item {
+	items := append([]Item(nil), b.Items...)
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Priority != items[j].Priority {
+			return items[i].Priority < items[j].Priority
+		}
+		return items[i].Created.Before(items[j].Created)
+	})
+	return items
+}
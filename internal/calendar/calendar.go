@@ -0,0 +1,478 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a meeting read from an iCalendar file. Recurring events are
+// expanded into one Event per occurrence.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+	AllDay  bool
+}
+
+// Key identifies one occurrence of an event
+func (e Event) Key() string {
+	return e.UID + "@" + e.Start.UTC().Format(time.RFC3339)
+}
+
+// Overlaps reports whether the event overlaps the range from-to
+func (e Event) Overlaps(from, to time.Time) bool {
+	return e.Start.Before(to) && e.End.After(from)
+}
+
+// maxOccurrences caps how many occurrences of a recurring event are
+// returned for one window
+const maxOccurrences = 1000
+
+// Load reads the events overlapping from-to from a .ics file, or from
+// every .ics file in a directory
+func Load(path string, from, to time.Time) ([]Event, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.ics"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var all []Event
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		evs, err := Parse(f, from, to)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		all = append(all, evs...)
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Start.Before(all[j].Start) })
+	return all, nil
+}
+
+// Between returns the timed events overlapping from-to, earliest first.
+// All-day events are skipped: they're rarely meetings.
+func Between(evs []Event, from, to time.Time) []Event {
+	var found []Event
+	for _, e := range evs {
+		if !e.AllDay && e.Overlaps(from, to) {
+			found = append(found, e)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Start.Before(found[j].Start) })
+	return found
+}
+
+// property is one content line: NAME;PARAM=VALUE:value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// vevent holds the properties of one VEVENT being parsed
+type vevent struct {
+	props   map[string]property
+	exdates []property
+}
+
+// Parse reads the events in an iCalendar stream that overlap from-to.
+// Recurring events are only expanded within the window. Cancelled events
+// and ones marked as free time are left out. A VEVENT with a RECURRENCE-ID
+// replaces the occurrence of its recurring event that it names.
+func Parse(r io.Reader, from, to time.Time) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		evs        []Event
+		overrides  []Event
+		overridden = map[string]bool{} // Keys of replaced occurrences
+		current    *vevent
+		depth      int // Nesting inside the VEVENT, e.g. VALARM
+	)
+	for _, line := range lines {
+		p, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case p.name == "BEGIN" && p.value == "VEVENT":
+			current = &vevent{props: map[string]property{}}
+			depth = 0
+		case current == nil:
+			continue
+		case p.name == "BEGIN":
+			depth++
+		case p.name == "END" && p.value == "VEVENT":
+			found, err := current.events(from, to)
+			if err != nil {
+				return nil, err
+			}
+			if id, ok := current.props["RECURRENCE-ID"]; ok {
+				// Even a cancelled override removes the occurrence
+				t, _, err := parseTime(id)
+				if err != nil {
+					return nil, fmt.Errorf("event %q: RECURRENCE-ID: %w", current.props["SUMMARY"].value, err)
+				}
+				overridden[Event{UID: current.props["UID"].value, Start: t}.Key()] = true
+				overrides = append(overrides, found...)
+			} else {
+				evs = append(evs, found...)
+			}
+			current = nil
+		case p.name == "END":
+			depth--
+		case depth > 0:
+			continue
+		case p.name == "EXDATE":
+			current.exdates = append(current.exdates, p)
+		default:
+			current.props[p.name] = p
+		}
+	}
+
+	kept := evs[:0]
+	for _, e := range evs {
+		if !overridden[e.Key()] {
+			kept = append(kept, e)
+		}
+	}
+	return append(kept, overrides...), nil
+}
+
+// events turns a parsed VEVENT into its occurrences overlapping from-to
+func (v *vevent) events(from, to time.Time) ([]Event, error) {
+	if strings.EqualFold(v.props["STATUS"].value, "CANCELLED") ||
+		strings.EqualFold(v.props["TRANSP"].value, "TRANSPARENT") {
+		return nil, nil
+	}
+
+	dtstart, ok := v.props["DTSTART"]
+	if !ok {
+		return nil, nil
+	}
+	start, allDay, err := parseTime(dtstart)
+	if err != nil {
+		return nil, fmt.Errorf("event %q: DTSTART: %w", v.props["SUMMARY"].value, err)
+	}
+
+	var length time.Duration
+	switch {
+	case v.props["DTEND"].value != "":
+		end, _, err := parseTime(v.props["DTEND"])
+		if err != nil {
+			return nil, fmt.Errorf("event %q: DTEND: %w", v.props["SUMMARY"].value, err)
+		}
+		length = end.Sub(start)
+	case v.props["DURATION"].value != "":
+		if length, err = parseDuration(v.props["DURATION"].value); err != nil {
+			return nil, fmt.Errorf("event %q: DURATION: %w", v.props["SUMMARY"].value, err)
+		}
+	case allDay:
+		length = 24 * time.Hour
+	}
+
+	base := Event{
+		UID:     v.props["UID"].value,
+		Summary: unescape(v.props["SUMMARY"].value),
+		AllDay:  allDay,
+	}
+
+	starts := []time.Time{start}
+	if rule := v.props["RRULE"].value; rule != "" {
+		// Occurrences starting up to one length before from still overlap
+		if starts, err = expand(start, rule, from.Add(-length), to); err != nil {
+			return nil, fmt.Errorf("event %q: RRULE: %w", base.Summary, err)
+		}
+	}
+
+	excluded := map[time.Time]bool{}
+	for _, p := range v.exdates {
+		for _, value := range strings.Split(p.value, ",") {
+			t, _, err := parseTime(property{params: p.params, value: value})
+			if err == nil {
+				excluded[t.UTC()] = true
+			}
+		}
+	}
+
+	var evs []Event
+	for _, s := range starts {
+		if excluded[s.UTC()] {
+			continue
+		}
+		e := base
+		e.Start = s
+		e.End = s.Add(length)
+		if e.Overlaps(from, to) {
+			evs = append(evs, e)
+		}
+	}
+	return evs, nil
+}
+
+// expand lists the start times of a recurring event after from and before
+// to. Occurrences before from aren't returned but still count towards
+// COUNT. Daily and weekly rules with INTERVAL, COUNT, UNTIL and (weekly)
+// BYDAY are supported; other rules only keep the first occurrence.
+func expand(start time.Time, rule string, from, to time.Time) ([]time.Time, error) {
+	parts := map[string]string{}
+	for _, part := range strings.Split(rule, ";") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			parts[strings.ToUpper(key)] = value
+		}
+	}
+
+	interval := 1
+	if value := parts["INTERVAL"]; value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid INTERVAL %q", value)
+		}
+		interval = n
+	}
+
+	count := math.MaxInt
+	if value := parts["COUNT"]; value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid COUNT %q", value)
+		}
+		count = n
+	}
+
+	var until time.Time
+	if value := parts["UNTIL"]; value != "" {
+		t, _, err := parseTime(property{value: value})
+		if err != nil {
+			return nil, fmt.Errorf("invalid UNTIL %q", value)
+		}
+		until = t
+	}
+
+	var (
+		starts []time.Time
+		seen   int // Occurrences so far, including the ones before from
+	)
+	// add takes the next occurrence, reporting false once the rule or the
+	// window has run out
+	add := func(t time.Time) bool {
+		if seen >= count || (!until.IsZero() && t.After(until)) || !t.Before(to) || len(starts) >= maxOccurrences {
+			return false
+		}
+		seen++
+		if t.After(from) {
+			starts = append(starts, t)
+		}
+		return true
+	}
+
+	switch strings.ToUpper(parts["FREQ"]) {
+	case "DAILY":
+		for t := start; add(t); t = t.AddDate(0, 0, interval) {
+		}
+
+	case "WEEKLY":
+		days := []time.Weekday{start.Weekday()}
+		if value := parts["BYDAY"]; value != "" {
+			days = nil
+			for _, day := range strings.Split(value, ",") {
+				wd, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %q", day)
+				}
+				days = append(days, wd)
+			}
+		}
+
+		// Walk week by week from the week containing start
+		week := start.AddDate(0, 0, -int(start.Weekday()))
+	weeks:
+		for {
+			for d := time.Sunday; d <= time.Saturday; d++ {
+				t := week.AddDate(0, 0, int(d))
+				if t.Before(start) || !containsDay(days, d) {
+					continue
+				}
+				if !add(t) {
+					break weeks
+				}
+			}
+			week = week.AddDate(0, 0, 7*interval)
+		}
+
+	default:
+		add(start)
+	}
+
+	return starts, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func containsDay(days []time.Weekday, d time.Weekday) bool {
+	for _, day := range days {
+		if day == d {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTime reads a DATE or DATE-TIME value: UTC ("...Z"), in a TZID, or
+// floating (local time)
+func parseTime(p property) (t time.Time, allDay bool, err error) {
+	value := strings.TrimSpace(p.value)
+
+	if p.params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err = time.ParseInLocation("20060102", value, time.Local)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	loc := time.Local
+	if tzid := p.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(strings.Trim(tzid, `"`)); err == nil {
+			loc = l
+		}
+	}
+	t, err = time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// parseDuration reads an iCalendar duration such as PT1H30M or P1D
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimLeft(value, "+-")
+	if !strings.HasPrefix(value, "P") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	var (
+		total  time.Duration
+		number string
+		inTime bool
+	)
+	for _, r := range value[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		number = ""
+
+		switch {
+		case r == 'W':
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D':
+			total += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+// unfold joins continuation lines (starting with a space or tab) onto the
+// line before them
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseProperty splits a content line into its name, parameters and value
+func parseProperty(line string) (property, bool) {
+	// The value starts at the first colon outside a quoted parameter
+	quoted := false
+	split := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			split = i
+			break
+		}
+	}
+	if split < 0 {
+		return property{}, false
+	}
+
+	head := strings.Split(line[:split], ";")
+	p := property{
+		name:   strings.ToUpper(head[0]),
+		params: map[string]string{},
+		value:  line[split+1:],
+	}
+	for _, param := range head[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			p.params[strings.ToUpper(key)] = value
+		}
+	}
+	return p, true
+}
+
+// unescape reverses TEXT escaping (RFC 5545 3.3.11)
+func unescape(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
@@ -0,0 +1,330 @@
+package calendar
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ics wraps VEVENT bodies in a calendar with CRLF line endings
+func ics(events ...string) string {
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n")
+	for _, e := range events {
+		b.WriteString("BEGIN:VEVENT\r\n")
+		for _, line := range strings.Split(strings.TrimSpace(e), "\n") {
+			b.WriteString(strings.TrimSpace(line) + "\r\n")
+		}
+		b.WriteString("END:VEVENT\r\n")
+	}
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String()
+}
+
+// wideFrom and wideTo span every event in the tests below
+var (
+	wideFrom = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	wideTo   = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func parse(t *testing.T, data string) []Event {
+	t.Helper()
+	return parseBetween(t, data, wideFrom, wideTo)
+}
+
+func parseBetween(t *testing.T, data string, from, to time.Time) []Event {
+	t.Helper()
+	evs, err := Parse(strings.NewReader(data), from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(evs, func(i, j int) bool { return evs[i].Start.Before(evs[j].Start) })
+	return evs
+}
+
+// starts lists the events' start times in UTC
+func starts(evs []Event) []string {
+	var s []string
+	for _, e := range evs {
+		s = append(s, e.Start.UTC().Format("Mon 2006-01-02 15:04"))
+	}
+	return s
+}
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	return loc
+}
+
+func TestParseRRULE(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		want  []string
+	}{
+		{
+			"single event",
+			"UID:1\nDTSTART:20260302T090000Z\nDTEND:20260302T093000Z",
+			[]string{"Mon 2026-03-02 09:00"},
+		},
+		{
+			"daily with count",
+			"UID:1\nDTSTART:20260302T090000Z\nRRULE:FREQ=DAILY;COUNT=3",
+			[]string{"Mon 2026-03-02 09:00", "Tue 2026-03-03 09:00", "Wed 2026-03-04 09:00"},
+		},
+		{
+			"daily interval until",
+			"UID:1\nDTSTART:20260302T090000Z\nRRULE:FREQ=DAILY;INTERVAL=2;UNTIL=20260306T090000Z",
+			[]string{"Mon 2026-03-02 09:00", "Wed 2026-03-04 09:00", "Fri 2026-03-06 09:00"},
+		},
+		{
+			"weekly by day",
+			"UID:1\nDTSTART:20260304T090000Z\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4",
+			[]string{"Wed 2026-03-04 09:00", "Fri 2026-03-06 09:00", "Mon 2026-03-09 09:00", "Wed 2026-03-11 09:00"},
+		},
+		{
+			"every other week",
+			"UID:1\nDTSTART:20260302T090000Z\nRRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			[]string{"Mon 2026-03-02 09:00", "Mon 2026-03-16 09:00", "Mon 2026-03-30 09:00"},
+		},
+		{
+			"unsupported rule keeps the first occurrence",
+			"UID:1\nDTSTART:20260302T090000Z\nRRULE:FREQ=MONTHLY;COUNT=3",
+			[]string{"Mon 2026-03-02 09:00"},
+		},
+		{
+			"cancelled",
+			"UID:1\nDTSTART:20260302T090000Z\nSTATUS:CANCELLED",
+			nil,
+		},
+		{
+			"free time",
+			"UID:1\nDTSTART:20260302T090000Z\nTRANSP:TRANSPARENT",
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := starts(parse(t, ics(tt.event)))
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("starts = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalidRRULE(t *testing.T) {
+	for _, rule := range []string{"FREQ=DAILY;COUNT=0", "FREQ=DAILY;INTERVAL=x", "FREQ=WEEKLY;BYDAY=XX"} {
+		_, err := Parse(strings.NewReader(ics("UID:1\nSUMMARY:Standup\nDTSTART:20260302T090000Z\nRRULE:"+rule)), wideFrom, wideTo)
+		if err == nil || !strings.Contains(err.Error(), "Standup") {
+			t.Errorf("RRULE:%s: err = %v, want an error naming the event", rule, err)
+		}
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	// A standup that has run every weekday since 2023
+	data := ics(
+		`
+		UID:standup
+		SUMMARY:Standup
+		DTSTART:20230102T090000Z
+		DURATION:PT15M
+		RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR
+		`,
+		`
+		UID:daily
+		SUMMARY:Check the queue
+		DTSTART:20230102T080000Z
+		DURATION:PT2H
+		RRULE:FREQ=DAILY
+		`,
+		// 1000 occurrences run out on 27 September 2025
+		`
+		UID:counted
+		SUMMARY:Retro
+		DTSTART:20230102T100000Z
+		RRULE:FREQ=DAILY;COUNT=1000
+		`,
+	)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []string
+	}{
+		{
+			"years after DTSTART",
+			time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC),
+			time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			// The queue check started at 8:00 and still runs at 8:30
+			[]string{"Mon 2026-10-19 08:00", "Mon 2026-10-19 09:00"},
+		},
+		{
+			"weekend",
+			time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+			[]string{"Sat 2026-10-17 08:00", "Sun 2026-10-18 08:00"},
+		},
+		{
+			"COUNT counts from DTSTART",
+			time.Date(2025, 9, 26, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 9, 28, 12, 0, 0, 0, time.UTC),
+			[]string{
+				"Fri 2025-09-26 08:00", "Fri 2025-09-26 09:00", "Fri 2025-09-26 10:00",
+				"Sat 2025-09-27 08:00", "Sat 2025-09-27 10:00",
+				"Sun 2025-09-28 08:00",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := starts(parseBetween(t, data, tt.from, tt.to))
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("starts = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEXDATE(t *testing.T) {
+	evs := parse(t, ics(`
+		UID:standup
+		DTSTART:20260302T090000Z
+		DURATION:PT15M
+		RRULE:FREQ=DAILY;COUNT=5
+		EXDATE:20260303T090000Z
+		EXDATE:20260305T090000Z,20260306T090000Z
+	`))
+
+	want := []string{"Mon 2026-03-02 09:00", "Wed 2026-03-04 09:00"}
+	if got := starts(evs); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("starts = %q, want %q", got, want)
+	}
+	if d := evs[0].End.Sub(evs[0].Start); d != 15*time.Minute {
+		t.Errorf("length = %s, want 15m", d)
+	}
+}
+
+func TestParseTZID(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+
+	// US clocks go forward on 8 March 2026: the meeting stays at 9:00 local
+	evs := parse(t, ics(`
+		UID:sync
+		DTSTART;TZID=America/New_York:20260302T090000
+		DTEND;TZID="America/New_York":20260302T100000
+		RRULE:FREQ=WEEKLY;COUNT=3
+		EXDATE;TZID=America/New_York:20260316T090000
+	`))
+
+	want := []string{"Mon 2026-03-02 14:00", "Mon 2026-03-09 13:00"}
+	if got := starts(evs); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("starts = %q, want %q", got, want)
+	}
+	for _, e := range evs {
+		if local := e.Start.In(newYork); local.Hour() != 9 || e.End.Sub(e.Start) != time.Hour {
+			t.Errorf("occurrence %s = %s for %s, want 9:00 for an hour", e.Start, local, e.End.Sub(e.Start))
+		}
+	}
+}
+
+func TestParseOverrides(t *testing.T) {
+	loadLocation(t, "Europe/Amsterdam")
+
+	evs := parse(t, ics(
+		`
+		UID:weekly
+		SUMMARY:Planning
+		DTSTART;TZID=Europe/Amsterdam:20260302T100000
+		DURATION:PT1H
+		RRULE:FREQ=WEEKLY;COUNT=4
+		`,
+		// Moved an hour later and renamed
+		`
+		UID:weekly
+		SUMMARY:Planning (moved)
+		RECURRENCE-ID;TZID=Europe/Amsterdam:20260309T100000
+		DTSTART;TZID=Europe/Amsterdam:20260309T110000
+		DURATION:PT1H
+		`,
+		// Cancelled, named in UTC rather than the master's zone
+		`
+		UID:weekly
+		SUMMARY:Planning
+		RECURRENCE-ID:20260316T090000Z
+		DTSTART:20260316T090000Z
+		STATUS:CANCELLED
+		`,
+		// Same time, different event
+		`
+		UID:other
+		SUMMARY:Lunch
+		DTSTART;TZID=Europe/Amsterdam:20260302T100000
+		DURATION:PT1H
+		`,
+	))
+
+	var got []string
+	for _, e := range evs {
+		got = append(got, e.Start.UTC().Format("01-02 15:04")+" "+e.Summary)
+	}
+	want := []string{
+		"03-02 09:00 Planning",
+		"03-02 09:00 Lunch",
+		"03-09 10:00 Planning (moved)",
+		"03-23 09:00 Planning",
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("events:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParseContentLines(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:1\r\n" +
+		"SUMMARY:Design review\\, part\r\n" +
+		"  two\r\n" +
+		"DTSTART:20260302T090000Z\r\n" +
+		"DTEND:20260302T100000Z\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"DTSTART:20260101T000000Z\r\n" +
+		"END:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:2\r\n" +
+		"DTSTART;VALUE=DATE:20260302\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	evs := parse(t, data)
+	if len(evs) != 2 {
+		t.Fatalf("parsed %d events, want 2", len(evs))
+	}
+
+	meeting, allDay := evs[0], evs[1]
+	if meeting.UID != "1" {
+		meeting, allDay = allDay, meeting
+	}
+	if meeting.Summary != "Design review, part two" || meeting.Start.Hour() != 9 {
+		t.Errorf("meeting = %+v; the alarm's DTSTART must not leak into it", meeting)
+	}
+	if !allDay.AllDay || allDay.End.Sub(allDay.Start) != 24*time.Hour {
+		t.Errorf("all-day event = %+v", allDay)
+	}
+
+	from := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	if found := Between(evs, from, from.Add(4*time.Hour)); len(found) != 1 || found[0].UID != "1" {
+		t.Errorf("Between = %+v, want only the timed meeting", found)
+	}
+}
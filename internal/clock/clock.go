@@ -0,0 +1,32 @@
+package clock
+
+import "time"
+
+// Clock tells the time. Code that schedules things takes a Clock so tests
+// can control time instead of sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the system clock
+type Real struct{}
+
+// Now returns the current local time
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Fake is a clock that only moves when told to
+type Fake struct {
+	T time.Time
+}
+
+// Now returns the fake's current time
+func (f *Fake) Now() time.Time {
+	return f.T
+}
+
+// Advance moves the fake clock forward by d
+func (f *Fake) Advance(d time.Duration) {
+	f.T = f.T.Add(d)
+}
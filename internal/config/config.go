@@ -0,0 +1,130 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+const configFile = ".focus/config.json"
+
+// Config holds user settings read from .focus/config.json
+type Config struct {
+	Watcher   Watcher    `json:"watcher"`
+	Reminders Reminders  `json:"reminders"`
+	Notifiers []Notifier `json:"notifiers,omitempty"`
+	Webhooks  []Webhook  `json:"webhooks,omitempty"`
+	Calendar  Calendar   `json:"calendar"`
+	Timesheet Timesheet  `json:"timesheet"`
+	Issues    Issues     `json:"issues"`
+
+	// Hooks maps lifecycle events ("pre-start", "on-drift", ...) to shell
+	// commands, run alongside executables in .focus/hooks/
+	Hooks map[string][]string `json:"hooks,omitempty"`
+}
+
+// Watcher holds settings for the background watcher
+type Watcher struct {
+	// IdleThreshold pauses focused time after this long without activity
+	// (e.g. "10m"). Use "0" to disable idle detection.
+	IdleThreshold string `json:"idle_threshold,omitempty"`
+}
+
+// Reminders configures when the watcher nudges you. Durations use Go
+// syntax ("15m"); unset fields keep their defaults.
+type Reminders struct {
+	Interval   string      `json:"interval,omitempty"`    // Regular check reminders, "0" disables
+	Warnings   []string    `json:"warnings,omitempty"`    // Time left to warn at before expiry
+	Nags       []string    `json:"nags,omitempty"`        // Gaps between nags after expiry
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"` // No reminders in this window
+}
+
+// QuietHours is a daily "HH:MM" window, which may wrap past midnight
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Calendar points focus at meetings exported from your calendar
+type Calendar struct {
+	Path      string `json:"path,omitempty"`       // .ics file, or a directory of them
+	HeadsUp   string `json:"heads_up,omitempty"`   // Warn this long before a meeting (default "5m")
+	AutoPause bool   `json:"auto_pause,omitempty"` // Stop focused time while a meeting runs
+}
+
+// Timesheet configures how focused time is logged as billable hours
+type Timesheet struct {
+	Project  string        `json:"project,omitempty"` // Defaults to the repository name
+	Client   string        `json:"client,omitempty"`
+	Billable bool          `json:"billable,omitempty"`
+	API      *TimesheetAPI `json:"api,omitempty"` // Where 'focus timesheet --push' sends entries
+}
+
+// TimesheetAPI is a JSON endpoint that accepts timesheet entries
+type TimesheetAPI struct {
+	URL      string            `json:"url"`
+	TokenEnv string            `json:"token_env,omitempty"` // Environment variable holding a bearer token
+	Headers  map[string]string `json:"headers,omitempty"`
+}
+
+// Issues configures the tracker issue titles are fetched from
+type Issues struct {
+	Provider string   `json:"provider,omitempty"`  // github, gitlab or jira
+	BaseURL  string   `json:"base_url,omitempty"`  // API root; defaults to the public GitHub/GitLab API
+	Repo     string   `json:"repo,omitempty"`      // GitHub "owner/repo" or GitLab "group/project"
+	TokenEnv string   `json:"token_env,omitempty"` // Environment variable holding an API token
+	Projects []string `json:"projects,omitempty"`  // Jira project keys picked up from task names ("PAY")
+}
+
+// Notifier configures one notification backend. Without any, focus uses
+// desktop notifications.
+type Notifier struct {
+	Type    string   `json:"type"`              // desktop, dbus, terminal, log or command
+	Path    string   `json:"path,omitempty"`    // Log file, or terminal device for "terminal"
+	Style   string   `json:"style,omitempty"`   // Terminal: bell, osc9 or both
+	Command []string `json:"command,omitempty"` // Command and arguments for "command"
+}
+
+// Webhook posts session lifecycle events to a URL so teammates can see
+// when you're focusing
+type Webhook struct {
+	URL     string   `json:"url"`
+	Format  string   `json:"format,omitempty"`  // json (default), slack or mattermost
+	Events  []string `json:"events,omitempty"`  // e.g. "session.started"; empty sends all
+	Retries *int     `json:"retries,omitempty"` // Extra attempts before queueing (default 3)
+}
+
+// Load reads the config file, returning an empty config if none exists
+func Load() (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", configFile, err)
+	}
+
+	return cfg, nil
+}
+
+// ParseDuration parses a duration setting, returning fallback if unset
+func ParseDuration(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if value == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const eventsDir = ".focus/events"
+
+// Type names something that happened during a session
+type Type string
+
+const (
+	SessionStarted  Type = "session.started"
+	SessionPaused   Type = "session.paused"
+	SessionResumed  Type = "session.resumed"
+	SessionExtended Type = "session.extended"
+	SessionEnded    Type = "session.ended"   // Data: outcome
+	CheckAnswered   Type = "check.answered"  // Data: answer, source
+	DriftLogged     Type = "drift.logged"    // Data: description, reason, category, tags, minutes_lost, severity
+	DriftParked     Type = "drift.parked"    // Data: session, branch, stashed
+	BranchLeft      Type = "branch.left"     // Data: branch
+	BranchReturned  Type = "branch.returned" // Data: branch, duration
+	IdleStarted     Type = "idle.started"
+	IdleEnded       Type = "idle.ended"    // Data: duration
+	IdleReviewed    Type = "idle.reviewed" // Data: break ("true"/"false")
+	ReminderSent    Type = "reminder.sent" // Data: kind, level
+	Snoozed         Type = "reminder.snoozed"
+	PomodoroPhase   Type = "pomodoro.phase"    // Data: phase, completed
+	IdeaCaptured    Type = "idea.captured"     // Data: id, title
+	StepAdded       Type = "step.added"        // Data: step, title
+	StepDone        Type = "step.done"         // Data: step, title
+	CriteriaChecked Type = "criteria.checked"  // Data: met, total
+	MeetingSoon     Type = "meeting.soon"      // Data: summary, start
+	MeetingStarted  Type = "meeting.started"   // Data: summary
+	MeetingEnded    Type = "meeting.ended"     // Data: duration
+	NoteAdded       Type = "note.added"        // Data: text
+	Reflected       Type = "session.reflected" // Data: action, rating
+)
+
+// Event is one line in a session's event log
+type Event struct {
+	Time      time.Time         `json:"time"`
+	Type      Type              `json:"type"`
+	SessionID string            `json:"session_id"`
+	Data      map[string]string `json:"data,omitempty"`
+}
+
+// New creates an event for a session happening now
+func New(sessionID string, t Type, data map[string]string) Event {
+	return Event{
+		Time:      time.Now(),
+		Type:      t,
+		SessionID: sessionID,
+		Data:      data,
+	}
+}
+
+// Append adds an event to the end of its session's log
+func Append(e Event) error {
+	if e.SessionID == "" {
+		return fmt.Errorf("event %s has no session", e.Type)
+	}
+
+	if err := os.MkdirAll(eventsDir, 0755); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path(e.SessionID), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// Load reads a session's event log in order. A session without a log has
+// no events.
+func Load(sessionID string) ([]Event, error) {
+	f, err := os.Open(path(sessionID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Event{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var log []Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Event
+		// Skip a line torn by a crash mid-write rather than losing the log
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		log = append(log, e)
+	}
+
+	return log, scanner.Err()
+}
+
+// Sessions returns the IDs of every session with an event log, including
+// sessions that have since been merged or discarded
+func Sessions() ([]string, error) {
+	entries, err := os.ReadDir(eventsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if id, ok := strings.CutSuffix(entry.Name(), ".jsonl"); ok && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func path(sessionID string) string {
+	return filepath.Join(eventsDir, sessionID+".jsonl")
+}
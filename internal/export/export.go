@@ -0,0 +1,192 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/session"
+	"github.com/n3sty/focus/internal/worklog"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatCSV      Format = "csv"
+	FormatJSON     Format = "json"
+	FormatICS      Format = "ics"
+)
+
+// Formats lists the supported formats
+var Formats = []Format{FormatMarkdown, FormatCSV, FormatJSON, FormatICS}
+
+// ParseFormat reads a format name, accepting "markdown" for md
+func ParseFormat(value string) (Format, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "markdown" {
+		return FormatMarkdown, nil
+	}
+	for _, f := range Formats {
+		if value == string(f) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("invalid format %q (want md, csv, json or ics)", value)
+}
+
+// Between keeps the sessions worked on between from and to, trimming their
+// intervals to the same range
+func Between(entries []worklog.Entry, from, to time.Time) []worklog.Entry {
+	var kept []worklog.Entry
+	for _, e := range entries {
+		intervals := worklog.Clip(e.Intervals, from, to)
+		if len(intervals) == 0 {
+			continue
+		}
+		kept = append(kept, worklog.Entry{Session: e.Session, Intervals: intervals})
+	}
+	return kept
+}
+
+// Write exports the sessions to w in the given format
+func Write(w io.Writer, format Format, entries []worklog.Entry) error {
+	switch format {
+	case FormatMarkdown:
+		return Markdown(w, entries)
+	case FormatCSV:
+		return CSV(w, entries)
+	case FormatJSON:
+		return JSON(w, entries)
+	case FormatICS:
+		return ICS(w, entries, time.Now())
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
+
+// Markdown writes a section per session with its intervals and drifts
+func Markdown(w io.Writer, entries []worklog.Entry) error {
+	var b strings.Builder
+
+	b.WriteString("# Focus sessions\n")
+	for _, e := range entries {
+		sess := e.Session
+		fmt.Fprintf(&b, "\n## %s\n\n", sess.Task)
+		fmt.Fprintf(&b, "- **Status:** %s\n", sess.Status)
+		fmt.Fprintf(&b, "- **Branch:** `%s`\n", sess.Branch)
+		if sess.Issue != nil {
+			fmt.Fprintf(&b, "- **Issue:** %s\n", sess.Issue.Ref())
+		}
+		fmt.Fprintf(&b, "- **Started:** %s\n", sess.StartTime.Format("2006-01-02 15:04"))
+		if sess.EndTime != nil {
+			fmt.Fprintf(&b, "- **Ended:** %s\n", sess.EndTime.Format("2006-01-02 15:04"))
+		}
+		fmt.Fprintf(&b, "- **Focused:** %s of %s\n", formatMinutes(worklog.Total(e.Intervals)), sess.TimeBox)
+
+		if len(e.Intervals) > 0 {
+			b.WriteString("\n| Start | End | Focused |\n|---|---|---|\n")
+			for _, i := range e.Intervals {
+				fmt.Fprintf(&b, "| %s | %s | %s |\n",
+					i.Start.Format("2006-01-02 15:04"), i.End.Format("15:04"), formatMinutes(i.Duration()))
+			}
+		}
+
+		if len(sess.Drifts) > 0 {
+			b.WriteString("\n**Drifts**\n\n")
+			for _, d := range sess.Drifts {
+				fmt.Fprintf(&b, "- %s %s", d.Timestamp.Format("15:04"), d.Description)
+				if details := d.Details(); details != "" {
+					fmt.Fprintf(&b, " _(%s)_", details)
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// csvHeader names the CSV columns, one row per focused interval
+var csvHeader = []string{
+	"date", "start", "end", "minutes", "session_id", "task", "branch", "status", "timebox", "drifts", "minutes_lost",
+}
+
+// CSV writes one row per focused interval, ready for a spreadsheet or
+// timesheet
+func CSV(w io.Writer, entries []worklog.Entry) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		sess := e.Session
+		lost := 0
+		for _, d := range sess.Drifts {
+			lost += d.MinutesLost
+		}
+
+		for _, i := range e.Intervals {
+			row := []string{
+				i.Start.Format("2006-01-02"),
+				i.Start.Format("15:04"),
+				i.End.Format("15:04"),
+				strconv.FormatFloat(i.Duration().Minutes(), 'f', 1, 64),
+				sess.ID,
+				sess.Task,
+				sess.Branch,
+				sess.Status,
+				sess.TimeBox,
+				strconv.Itoa(len(sess.Drifts)),
+				strconv.Itoa(lost),
+			}
+			if err := out.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// jsonSession is a session with its focused intervals, as exported
+type jsonSession struct {
+	*session.Session
+	FocusedMinutes float64            `json:"focused_minutes"`
+	Intervals      []worklog.Interval `json:"intervals"`
+}
+
+// JSON writes the sessions, including drifts, with their focused intervals
+func JSON(w io.Writer, entries []worklog.Entry) error {
+	sessions := make([]jsonSession, 0, len(entries))
+	for _, e := range entries {
+		sessions = append(sessions, jsonSession{
+			Session:        e.Session,
+			FocusedMinutes: math.Round(worklog.Total(e.Intervals).Minutes()*10) / 10,
+			Intervals:      e.Intervals,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sessions)
+}
+
+// formatMinutes renders a duration as "1h 20m" or "45m"
+func formatMinutes(d time.Duration) string {
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+
+	if h > 0 {
+		return fmt.Sprintf("%dh %dm", h, m)
+	}
+	return fmt.Sprintf("%dm", m)
+}
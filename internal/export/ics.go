@@ -0,0 +1,82 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/worklog"
+)
+
+// icsTime is the UTC date-time form used by iCalendar
+const icsTime = "20060102T150405Z"
+
+// ICS writes a calendar with one event per focused interval, so focus
+// blocks show up next to meetings
+func ICS(w io.Writer, entries []worklog.Entry, now time.Time) error {
+	var b strings.Builder
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//n3sty//focus//EN")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+
+	for _, e := range entries {
+		sess := e.Session
+
+		description := fmt.Sprintf("Branch: %s\nStatus: %s\nTimebox: %s", sess.Branch, sess.Status, sess.TimeBox)
+		if len(sess.Drifts) > 0 {
+			description += fmt.Sprintf("\nDrifts: %d", len(sess.Drifts))
+			for _, d := range sess.Drifts {
+				description += fmt.Sprintf("\n- %s %s", d.Timestamp.Format("15:04"), d.Description)
+			}
+		}
+
+		for n, i := range e.Intervals {
+			writeLine(&b, "BEGIN:VEVENT")
+			// Stable across exports so re-importing updates instead of duplicating
+			writeLine(&b, fmt.Sprintf("UID:%s-%d@focus", sess.ID, i.Start.Unix()))
+			writeLine(&b, "DTSTAMP:"+now.UTC().Format(icsTime))
+			writeLine(&b, "DTSTART:"+i.Start.UTC().Format(icsTime))
+			writeLine(&b, "DTEND:"+i.End.UTC().Format(icsTime))
+			summary := "🎯 " + sess.Task
+			if len(e.Intervals) > 1 {
+				summary += fmt.Sprintf(" (%d/%d)", n+1, len(e.Intervals))
+			}
+			writeLine(&b, "SUMMARY:"+escapeText(summary))
+			writeLine(&b, "DESCRIPTION:"+escapeText(description))
+			writeLine(&b, "CATEGORIES:Focus")
+			writeLine(&b, "TRANSP:OPAQUE")
+			writeLine(&b, "END:VEVENT")
+		}
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeText escapes a TEXT value (RFC 5545 3.3.11)
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// writeLine writes a content line, folded at 75 octets without splitting
+// UTF-8 sequences (RFC 5545 3.1)
+func writeLine(b *strings.Builder, line string) {
+	const limit = 75
+
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+}
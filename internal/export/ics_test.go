@@ -0,0 +1,114 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/n3sty/focus/internal/session"
+	"github.com/n3sty/focus/internal/worklog"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Write the parser", "Write the parser"},
+		{"Fix a, b; c", `Fix a\, b\; c`},
+		{`C:\focus`, `C:\\focus`},
+		{"one\ntwo", `one\ntwo`},
+		{`already \, escaped`, `already \\\, escaped`},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		lines int // Physical lines after folding
+	}{
+		{"short", "SUMMARY:Write the parser", 1},
+		{"exactly 75 octets", strings.Repeat("a", 75), 1},
+		{"76 octets", strings.Repeat("a", 76), 2},
+		{"long", "DESCRIPTION:" + strings.Repeat("0123456789", 20), 3},
+		{"multibyte on the fold", strings.Repeat("a", 73) + "é🎯é", 2},
+		{"all emoji", "SUMMARY:" + strings.Repeat("🎯", 40), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			writeLine(&b, tt.line)
+			out := b.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("output %q doesn't end in CRLF", out)
+			}
+			physical := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(physical) != tt.lines {
+				t.Errorf("folded into %d lines, want %d: %q", len(physical), tt.lines, physical)
+			}
+			for i, p := range physical {
+				if len(p) > 75 {
+					t.Errorf("line %d is %d octets, want at most 75", i, len(p))
+				}
+				if i > 0 && !strings.HasPrefix(p, " ") {
+					t.Errorf("continuation line %d = %q, want a leading space", i, p)
+				}
+				if !utf8.ValidString(p) {
+					t.Errorf("line %d = %q splits a UTF-8 sequence", i, p)
+				}
+			}
+
+			// Unfolding gives the original line back
+			if unfolded := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", ""); unfolded != tt.line {
+				t.Errorf("unfolded = %q, want %q", unfolded, tt.line)
+			}
+		})
+	}
+}
+
+func TestICS(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	sess := &session.Session{
+		ID:      "parser",
+		Task:    "Fix the parser, then ship",
+		Branch:  "focus/parser",
+		Status:  "completed",
+		TimeBox: "2h",
+	}
+	entries := []worklog.Entry{{Session: sess, Intervals: []worklog.Interval{
+		{Start: start, End: start.Add(30 * time.Minute)},
+		{Start: start.Add(time.Hour), End: start.Add(90 * time.Minute)},
+	}}}
+
+	var b strings.Builder
+	if err := ICS(&b, entries, start.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	out := strings.ReplaceAll(b.String(), "\r\n ", "")
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:parser-1772442000@focus\r\n",
+		"DTSTART:20260302T090000Z\r\nDTEND:20260302T093000Z\r\n",
+		`SUMMARY:🎯 Fix the parser\, then ship (2/2)` + "\r\n",
+		`DESCRIPTION:Branch: focus/parser\nStatus: completed\nTimebox: 2h` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar is missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("wrote %d events, want one per interval", n)
+	}
+}
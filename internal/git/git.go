@@ -3,14 +3,20 @@ package git
 import (
 	"fmt"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/n3sty/focus/internal/issue"
 )
 
-// CreateFocusBranch creates a new git branch for the focus session
-func CreateFocusBranch(task string) (string, error) {
+// CreateFocusBranch creates a new git branch for the focus session. An
+// issue reference ("#123", "ABC-123"), if given, goes into the branch name
+// and the start commit.
+func CreateFocusBranch(task, issue string) (string, error) {
 	// Generate branch name from task
-	branchName := fmt.Sprintf("focus/%s", slugify(task))
+	branchName := fmt.Sprintf("focus/%s", slugify(withIssueSlug(task, issue)))
 
 	// Create and checkout branch
 	cmd := exec.Command("git", "checkout", "-b", branchName)
@@ -19,7 +25,7 @@ func CreateFocusBranch(task string) (string, error) {
 	}
 
 	// Make empty commit to mark start
-	commitMsg := fmt.Sprintf("🎯 START: %s", task)
+	commitMsg := fmt.Sprintf("🎯 START: %s", withIssue(task, issue))
 	cmd = exec.Command("git", "commit", "--allow-empty", "-m", commitMsg)
 	if err := cmd.Run(); err != nil {
 		return "", fmt.Errorf("failed to create start commit: %w", err)
@@ -28,10 +34,91 @@ func CreateFocusBranch(task string) (string, error) {
 	return branchName, nil
 }
 
-// GetCommitsSince returns the number of commits since a given time
-func GetCommitsSince(since time.Time) (int, error) {
+// CreateParkedBranch creates a focus branch for task off base without
+// checking it out, so the current work tree is left alone
+func CreateParkedBranch(task, base string) (string, error) {
+	branchName := fmt.Sprintf("focus/%s", slugify(task))
+
+	if exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branchName).Run() == nil {
+		return "", fmt.Errorf("branch %s already exists", branchName)
+	}
+
+	// Build the start marker commit directly on top of base
+	commitMsg := fmt.Sprintf("🎯 START: %s", task)
+	output, err := exec.Command("git", "commit-tree", base+"^{tree}", "-p", base, "-m", commitMsg).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to create start commit: %w", err)
+	}
+
+	cmd := exec.Command("git", "branch", branchName, strings.TrimSpace(string(output)))
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to create branch: %w", err)
+	}
+
+	return branchName, nil
+}
+
+// BaseBranch returns the branch focus branches merge into: main, or
+// master in older repositories
+func BaseBranch() (string, error) {
+	for _, branch := range []string{"main", "master"} {
+		if exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch).Run() == nil {
+			return branch, nil
+		}
+	}
+	return "", fmt.Errorf("no main or master branch found")
+}
+
+// excludeFocus keeps focus's own state directory out of status and stash
+const excludeFocus = ":(exclude).focus"
+
+// HasChanges reports whether the work tree has uncommitted or untracked
+// changes
+func HasChanges() bool {
+	output, err := exec.Command("git", "status", "--porcelain", "--", ".", excludeFocus).Output()
+	return err == nil && len(strings.TrimSpace(string(output))) > 0
+}
+
+// Stash saves uncommitted and untracked changes and returns the stash
+// commit, which stays valid even as other stashes come and go
+func Stash(message string) (string, error) {
+	if err := exec.Command("git", "stash", "push", "--include-untracked", "-m", message, "--", ".", excludeFocus).Run(); err != nil {
+		return "", fmt.Errorf("failed to stash changes: %w", err)
+	}
+
+	output, err := exec.Command("git", "rev-parse", "stash@{0}").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find stash: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// PopStash applies a stash commit from Stash and drops it from the list
+func PopStash(commit string) error {
+	if err := exec.Command("git", "stash", "apply", commit).Run(); err != nil {
+		return fmt.Errorf("failed to apply stash %s: %w", commit, err)
+	}
+
+	output, err := exec.Command("git", "stash", "list", "--format=%H").Output()
+	if err != nil {
+		return nil // Applied; leaving the entry behind is harmless
+	}
+	for i, hash := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if hash == commit {
+			exec.Command("git", "stash", "drop", fmt.Sprintf("stash@{%d}", i)).Run()
+			break
+		}
+	}
+	return nil
+}
+
+// GetCommitsSince returns the number of commits on a branch since a given time
+func GetCommitsSince(branch string, since time.Time) (int, error) {
+	if branch == "" {
+		branch = "HEAD"
+	}
 	sinceStr := since.Format("2006-01-02T15:04:05")
-	cmd := exec.Command("git", "rev-list", "--count", "--since="+sinceStr, "HEAD")
+	cmd := exec.Command("git", "rev-list", "--count", "--since="+sinceStr, branch)
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -43,6 +130,45 @@ func GetCommitsSince(since time.Time) (int, error) {
 	return count, nil
 }
 
+// Commit is a commit on a focus branch
+type Commit struct {
+	Hash    string
+	Time    time.Time
+	Subject string
+}
+
+// GetCommits returns commits on a branch since a given time, oldest first
+func GetCommits(branch string, since time.Time) ([]Commit, error) {
+	if branch == "" {
+		branch = "HEAD"
+	}
+	sinceStr := since.Format("2006-01-02T15:04:05")
+	cmd := exec.Command("git", "log", "--reverse", "--format=%h%x09%ct%x09%s", "--since="+sinceStr, branch)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		unix, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		commits = append(commits, Commit{
+			Hash:    parts[0],
+			Time:    time.Unix(unix, 0),
+			Subject: parts[2],
+		})
+	}
+	return commits, nil
+}
+
 // GetCurrentBranch returns the current git branch name
 func GetCurrentBranch() (string, error) {
 	cmd := exec.Command("git", "branch", "--show-current")
@@ -53,8 +179,54 @@ func GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// MergeToMain merges the current branch to main and deletes the focus branch
-func MergeToMain(task string) error {
+// GetUserName returns the configured git user.name, if any
+func GetUserName() string {
+	output, err := exec.Command("git", "config", "user.name").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// GetUserEmail returns the configured git user.email, if any
+func GetUserEmail() string {
+	output, err := exec.Command("git", "config", "user.email").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// RepoName returns the repository's name: the last part of the origin
+// remote's URL, or the name of the top-level directory without one
+func RepoName() string {
+	if output, err := exec.Command("git", "remote", "get-url", "origin").Output(); err == nil {
+		url := strings.TrimSuffix(strings.TrimSpace(string(output)), "/")
+		url = strings.TrimSuffix(url, ".git")
+		if i := strings.LastIndexAny(url, "/:"); i >= 0 && i < len(url)-1 {
+			return url[i+1:]
+		}
+	}
+
+	output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(strings.TrimSpace(string(output)))
+}
+
+// SwitchBranch checks out an existing branch
+func SwitchBranch(branch string) error {
+	cmd := exec.Command("git", "switch", branch)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to switch to %s: %w", branch, err)
+	}
+	return nil
+}
+
+// MergeToMain merges the current branch to main and deletes the focus
+// branch. The merge commit mentions the issue reference, if any.
+func MergeToMain(task, issue string) error {
 	currentBranch, err := GetCurrentBranch()
 	if err != nil {
 		return err
@@ -71,7 +243,7 @@ func MergeToMain(task string) error {
 	}
 
 	// Merge with no-ff to preserve history
-	commitMsg := fmt.Sprintf("✅ Completed: %s", task)
+	commitMsg := fmt.Sprintf("✅ Completed: %s", withIssue(task, issue))
 	cmd = exec.Command("git", "merge", "--no-ff", currentBranch, "-m", commitMsg)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to merge: %w", err)
@@ -117,6 +289,24 @@ func IsGitRepo() bool {
 	return cmd.Run() == nil
 }
 
+// withIssue appends the issue reference to a commit subject unless the
+// task already mentions it
+func withIssue(task, ref string) string {
+	if ref == "" || issue.Mentions(task, ref) {
+		return task
+	}
+	return fmt.Sprintf("%s (%s)", task, ref)
+}
+
+// withIssueSlug puts the issue first in a branch name unless the task
+// already mentions it. Branch names are lowercase, so "pay-88" counts too.
+func withIssueSlug(task, ref string) string {
+	if ref == "" || issue.Mentions(strings.ToUpper(task), strings.ToUpper(ref)) {
+		return task
+	}
+	return strings.TrimPrefix(ref, "#") + " " + task
+}
+
 // slugify converts a task name to a git-safe branch name
 func slugify(s string) string {
 	s = strings.ToLower(s)
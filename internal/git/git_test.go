@@ -0,0 +1,46 @@
+package git
+
+import "testing"
+
+func TestWithIssue(t *testing.T) {
+	tests := []struct {
+		task, ref string
+		want      string
+	}{
+		{"Fix upload timeout", "#12", "Fix upload timeout (#12)"},
+		{"Fix upload timeout #12", "#12", "Fix upload timeout #12"},
+		{"Bump to v1.12", "#12", "Bump to v1.12 (#12)"},
+		{"Follow up on #123", "#12", "Follow up on #123 (#12)"},
+		{"PAY-88 refund rounding", "PAY-88", "PAY-88 refund rounding"},
+		{"PAY-888 refund rounding", "PAY-88", "PAY-888 refund rounding (PAY-88)"},
+		{"Fix upload timeout", "", "Fix upload timeout"},
+	}
+
+	for _, tt := range tests {
+		if got := withIssue(tt.task, tt.ref); got != tt.want {
+			t.Errorf("withIssue(%q, %q) = %q, want %q", tt.task, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestWithIssueSlug(t *testing.T) {
+	tests := []struct {
+		task, ref string
+		want      string
+	}{
+		{"Fix upload timeout", "#12", "focus/12-fix-upload-timeout"},
+		{"Fix upload timeout #12", "#12", "focus/fix-upload-timeout-12"},
+		{"Bump to v1.12", "#12", "focus/12-bump-to-v112"},
+		{"Follow up on #123", "#12", "focus/12-follow-up-on-123"},
+		{"PAY-88 refund rounding", "PAY-88", "focus/pay-88-refund-rounding"},
+		{"pay-88 refund rounding", "PAY-88", "focus/pay-88-refund-rounding"},
+		{"PAY-888 refund rounding", "PAY-88", "focus/pay-88-pay-888-refund-rounding"},
+		{"Fix upload timeout", "", "focus/fix-upload-timeout"},
+	}
+
+	for _, tt := range tests {
+		if got := "focus/" + slugify(withIssueSlug(tt.task, tt.ref)); got != tt.want {
+			t.Errorf("branch for %q, %q = %q, want %q", tt.task, tt.ref, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/session"
+)
+
+const hooksDir = ".focus/hooks"
+
+// timeout stops a stuck hook from hanging focus
+const timeout = time.Minute
+
+// Lifecycle events hooks can run on
+const (
+	PreStart  = "pre-start"
+	PostStart = "post-start"
+	OnCheck   = "on-check"
+	OnDrift   = "on-drift"
+	OnExpire  = "on-expire"
+	PreEnd    = "pre-end"
+	PostEnd   = "post-end"
+)
+
+// Events lists every hook event
+var Events = []string{PreStart, PostStart, OnCheck, OnDrift, OnExpire, PreEnd, PostEnd}
+
+// VetoError is returned when a pre-* hook exits non-zero
+type VetoError struct {
+	Event string
+	Hook  string
+	Err   error
+}
+
+func (e *VetoError) Error() string {
+	return fmt.Sprintf("%s hook %s vetoed: %v", e.Event, e.Hook, e.Err)
+}
+
+func (e *VetoError) Unwrap() error {
+	return e.Err
+}
+
+// Runner runs hooks: an executable named after the event in .focus/hooks/
+// and any shell commands configured for it
+type Runner struct {
+	Commands map[string][]string // Event name to shell commands
+	Stdout   io.Writer           // Hook output, discarded if nil
+	Stderr   io.Writer
+}
+
+// FromConfig returns a runner for the configured hook commands
+func FromConfig(commands map[string][]string) (Runner, error) {
+	for event := range commands {
+		if !isEvent(event) {
+			return Runner{}, fmt.Errorf("hooks: unknown event %q (want one of %s)", event, strings.Join(Events, ", "))
+		}
+	}
+	return Runner{Commands: commands}, nil
+}
+
+func isEvent(name string) bool {
+	for _, e := range Events {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs every hook for event. The session is passed as JSON on stdin
+// and as FOCUS_* environment variables, plus any extra env. For pre-*
+// events the first failing hook vetoes the action and stops the rest;
+// otherwise all hooks run and failures are joined.
+func (r Runner) Run(event string, sess *session.Session, env map[string]string) error {
+	payload, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+
+	environ := append(os.Environ(), sessionEnv(event, sess)...)
+	for k, v := range env {
+		environ = append(environ, k+"="+v)
+	}
+
+	var errs []error
+	for _, hook := range r.hooksFor(event) {
+		err := r.run(hook, payload, environ)
+		if err == nil {
+			continue
+		}
+		if strings.HasPrefix(event, "pre-") {
+			return &VetoError{Event: event, Hook: hook.name, Err: err}
+		}
+		errs = append(errs, fmt.Errorf("%s hook %s failed: %w", event, hook.name, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// hook is one thing to run for an event
+type hook struct {
+	name string
+	argv []string
+}
+
+func (r Runner) hooksFor(event string) []hook {
+	var found []hook
+
+	path := filepath.Join(hooksDir, event)
+	if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+		abs, err := filepath.Abs(path)
+		if err == nil {
+			found = append(found, hook{name: path, argv: []string{abs}})
+		}
+	}
+
+	for _, command := range r.Commands[event] {
+		found = append(found, hook{name: fmt.Sprintf("%q", command), argv: []string{"sh", "-c", command}})
+	}
+
+	return found
+}
+
+func (r Runner) run(h hook, payload []byte, environ []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, h.argv[0], h.argv[1:]...)
+	cmd.Env = environ
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = r.Stdout
+	cmd.Stderr = r.Stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		return err
+	}
+	return nil
+}
+
+// sessionEnv describes the session in environment variables
+func sessionEnv(event string, sess *session.Session) []string {
+	env := []string{
+		"FOCUS_EVENT=" + event,
+		"FOCUS_SESSION_ID=" + sess.ID,
+		"FOCUS_TASK=" + sess.Task,
+		"FOCUS_BRANCH=" + sess.Branch,
+		"FOCUS_TIMEBOX=" + sess.TimeBox,
+		"FOCUS_STATUS=" + sess.Status,
+		fmt.Sprintf("FOCUS_DRIFTS=%d", len(sess.Drifts)),
+	}
+	if sess.Issue != nil {
+		env = append(env, "FOCUS_ISSUE="+sess.Issue.Ref())
+	}
+	return env
+}
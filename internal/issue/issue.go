@@ -0,0 +1,257 @@
+package issue
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/config"
+)
+
+// Issue is a tracker issue a session works on
+type Issue struct {
+	Key   string `json:"key"`             // "123" (GitHub, GitLab) or "ABC-123" (Jira)
+	Title string `json:"title,omitempty"` // Fetched from the tracker, if configured
+	URL   string `json:"url,omitempty"`
+}
+
+// Ref returns how the issue is written in commits: "#123" or "ABC-123"
+func (i Issue) Ref() string {
+	if isNumber(i.Key) {
+		return "#" + i.Key
+	}
+	return i.Key
+}
+
+var (
+	numberKey = regexp.MustCompile(`^#?(\d+)$`)
+	jiraKey   = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9]+-\d+)$`)
+
+	// References inside a task description
+	numberInTask = regexp.MustCompile(`(?:^|[\s(\[])#(\d+)\b`)
+	jiraInTask   = regexp.MustCompile(`\b([A-Z][A-Z0-9]+-\d+)\b`)
+)
+
+// ParseKey reads an issue reference given on the command line: 123, #123
+// or ABC-123
+func ParseKey(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if m := numberKey.FindStringSubmatch(value); m != nil {
+		return m[1], nil
+	}
+	if m := jiraKey.FindStringSubmatch(value); m != nil {
+		return strings.ToUpper(m[1]), nil
+	}
+	return "", fmt.Errorf("invalid issue %q (want 123, #123 or ABC-123)", value)
+}
+
+// FromTask finds the first issue reference in a task: #123, or ABC-123
+// for one of the given Jira projects. Keys of other projects are ignored,
+// so names like UTF-8 or SHA-256 aren't taken for issues.
+func FromTask(task string, projects []string) (string, bool) {
+	key, at := "", len(task)
+	if m := numberInTask.FindStringSubmatchIndex(task); m != nil {
+		key, at = task[m[2]:m[3]], m[2]
+	}
+	for _, m := range jiraInTask.FindAllStringSubmatchIndex(task, -1) {
+		if m[2] > at {
+			break
+		}
+		if candidate := task[m[2]:m[3]]; inProjects(candidate, projects) {
+			key = candidate
+			break
+		}
+	}
+	return key, key != ""
+}
+
+// Mentions reports whether text refers to ref ("#123" or "ABC-123") as a
+// reference of its own, not as part of a longer one like #1234 or v1.123
+func Mentions(text, ref string) bool {
+	pattern, key := jiraInTask, ref
+	if number, ok := strings.CutPrefix(ref, "#"); ok {
+		pattern, key = numberInTask, number
+	}
+	for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+		if m[1] == key {
+			return true
+		}
+	}
+	return false
+}
+
+// inProjects reports whether a Jira key belongs to one of projects
+func inProjects(key string, projects []string) bool {
+	project, _, _ := strings.Cut(key, "-")
+	for _, p := range projects {
+		if strings.EqualFold(p, project) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Provider looks up issues in a tracker
+type Provider interface {
+	Fetch(key string) (*Issue, error)
+}
+
+// FromConfig builds the configured provider, or nil if none is set up
+func FromConfig(cfg config.Issues) (Provider, error) {
+	if cfg.Provider == "" {
+		return nil, nil
+	}
+
+	token := ""
+	if cfg.TokenEnv != "" {
+		token = os.Getenv(cfg.TokenEnv)
+	}
+	client := apiClient{token: token, http: &http.Client{Timeout: 10 * time.Second}}
+
+	switch cfg.Provider {
+	case "github":
+		if cfg.Repo == "" {
+			return nil, fmt.Errorf("issues.repo is required for github (e.g., \"org/repo\")")
+		}
+		return GitHub{BaseURL: orDefault(cfg.BaseURL, "https://api.github.com"), Repo: cfg.Repo, client: client}, nil
+	case "gitlab":
+		if cfg.Repo == "" {
+			return nil, fmt.Errorf("issues.repo is required for gitlab (e.g., \"group/project\")")
+		}
+		return GitLab{BaseURL: orDefault(cfg.BaseURL, "https://gitlab.com"), Repo: cfg.Repo, client: client}, nil
+	case "jira":
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("issues.base_url is required for jira (e.g., \"https://acme.atlassian.net\")")
+		}
+		return Jira{BaseURL: cfg.BaseURL, client: client}, nil
+	}
+	return nil, fmt.Errorf("unknown issues.provider %q (want github, gitlab or jira)", cfg.Provider)
+}
+
+// GitHub fetches issues from the GitHub REST API
+type GitHub struct {
+	BaseURL string // API root, e.g. https://api.github.com
+	Repo    string // owner/name
+	client  apiClient
+}
+
+func (g GitHub) Fetch(key string) (*Issue, error) {
+	if !isNumber(key) {
+		return nil, fmt.Errorf("GitHub issues are numbers, not %q", key)
+	}
+
+	var body struct {
+		Title   string `json:"title"`
+		HTMLURL string `json:"html_url"`
+	}
+	endpoint := fmt.Sprintf("%s/repos/%s/issues/%s", strings.TrimSuffix(g.BaseURL, "/"), g.Repo, key)
+	if err := g.client.get(endpoint, "token", &body); err != nil {
+		return nil, err
+	}
+	return &Issue{Key: key, Title: body.Title, URL: body.HTMLURL}, nil
+}
+
+// GitLab fetches issues from the GitLab REST API
+type GitLab struct {
+	BaseURL string // Instance root, e.g. https://gitlab.com
+	Repo    string // group/project
+	client  apiClient
+}
+
+func (g GitLab) Fetch(key string) (*Issue, error) {
+	if !isNumber(key) {
+		return nil, fmt.Errorf("GitLab issues are numbers, not %q", key)
+	}
+
+	var body struct {
+		Title  string `json:"title"`
+		WebURL string `json:"web_url"`
+	}
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/issues/%s",
+		strings.TrimSuffix(g.BaseURL, "/"), url.PathEscape(g.Repo), key)
+	if err := g.client.get(endpoint, "Bearer", &body); err != nil {
+		return nil, err
+	}
+	return &Issue{Key: key, Title: body.Title, URL: body.WebURL}, nil
+}
+
+// Jira fetches issues from the Jira REST API
+type Jira struct {
+	BaseURL string // Site root, e.g. https://acme.atlassian.net
+	client  apiClient
+}
+
+func (j Jira) Fetch(key string) (*Issue, error) {
+	if isNumber(key) {
+		return nil, fmt.Errorf("Jira issues look like ABC-123, not %q", key)
+	}
+
+	var body struct {
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	}
+	base := strings.TrimSuffix(j.BaseURL, "/")
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary", base, url.PathEscape(key))
+	if err := j.client.get(endpoint, "Bearer", &body); err != nil {
+		return nil, err
+	}
+	return &Issue{Key: key, Title: body.Fields.Summary, URL: base + "/browse/" + key}, nil
+}
+
+// apiClient makes authenticated JSON requests
+type apiClient struct {
+	token string
+	http  *http.Client
+}
+
+// get fetches endpoint into v, sending the token with the given
+// Authorization scheme
+func (c apiClient) get(endpoint, scheme string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", scheme+" "+c.token)
+	}
+
+	client := c.http
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("%s returned %s: %s", endpoint, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,308 @@
+package issue
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/n3sty/focus/internal/config"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"123", "123", false},
+		{"#123", "123", false},
+		{" #42 ", "42", false},
+		{"ABC-123", "ABC-123", false},
+		{"abc-7", "ABC-7", false},
+		{"PROJ2-15", "PROJ2-15", false},
+		{"", "", true},
+		{"#", "", true},
+		{"##12", "", true},
+		{"12a", "", true},
+		{"A-1", "", true},
+		{"ABC-", "", true},
+		{"1BC-12", "", true},
+		{"ABC-12 extra", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseKey(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseKey(%q) = %q, %v; want %q, error: %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFromTask(t *testing.T) {
+	tests := []struct {
+		task string
+		want string
+	}{
+		{"Fix #123", "123"},
+		{"#45 flaky test", "45"},
+		{"Retry uploads (#9)", "9"},
+		{"Retry uploads [#10]", "10"},
+		{"PAY-88 refund rounding", "PAY-88"},
+		{"Follow up on PAY-88 and #12", "PAY-88"}, // First one wins
+		{"Follow up on #12 and PAY-88", "12"},
+		{"Port OPS-4 fixes to PAY-88", "PAY-88"}, // Not a configured project
+		{"Handle issue#12 anchors", ""},
+		{"lowercase pay-12", ""},
+		{"Bump to UTF-8", ""},
+		{"Verify SHA-256 checksums", ""},
+		{"Parse ISO-8601 dates", ""},
+		{"Plain task", ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := FromTask(tt.task, []string{"pay", "CORE"})
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("FromTask(%q) = %q, %v; want %q", tt.task, got, ok, tt.want)
+		}
+	}
+}
+
+func TestFromTaskWithoutProjects(t *testing.T) {
+	if got, ok := FromTask("PAY-88 refund rounding", nil); ok {
+		t.Errorf("FromTask = %q without Jira projects, want nothing", got)
+	}
+	if got, _ := FromTask("PAY-88 refund rounding #7", nil); got != "7" {
+		t.Errorf("FromTask = %q, want the number", got)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		text string
+		ref  string
+		want bool
+	}{
+		{"Fix #12", "#12", true},
+		{"Retry uploads (#12)", "#12", true},
+		{"Fix #123", "#12", false},
+		{"Fix #1", "#12", false},
+		{"Bump to v1.12", "#12", false},
+		{"See issue#12", "#12", false},
+		{"PAY-88 refund rounding", "PAY-88", true},
+		{"PAY-888 refund rounding", "PAY-88", false},
+		{"XPAY-88 refund rounding", "PAY-88", false},
+		{"Refund #88", "PAY-88", false},
+		{"Plain task", "#12", false},
+	}
+
+	for _, tt := range tests {
+		if got := Mentions(tt.text, tt.ref); got != tt.want {
+			t.Errorf("Mentions(%q, %q) = %v, want %v", tt.text, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestRef(t *testing.T) {
+	if ref := (Issue{Key: "123"}).Ref(); ref != "#123" {
+		t.Errorf("Ref = %q, want #123", ref)
+	}
+	if ref := (Issue{Key: "ABC-1"}).Ref(); ref != "ABC-1" {
+		t.Errorf("Ref = %q, want ABC-1", ref)
+	}
+}
+
+// request is what a tracker stub saw
+type request struct {
+	path   string // Escaped, as sent
+	query  string
+	auth   string
+	accept string
+}
+
+// tracker serves body for every request and records what it was asked
+func tracker(t *testing.T, status int, body string) (*httptest.Server, *request) {
+	t.Helper()
+	seen := &request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*seen = request{
+			path:   r.URL.EscapedPath(),
+			query:  r.URL.RawQuery,
+			auth:   r.Header.Get("Authorization"),
+			accept: r.Header.Get("Accept"),
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, seen
+}
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Issues
+		key      string
+		body     string
+		wantPath string
+		wantQry  string
+		wantAuth string
+		want     Issue
+	}{
+		{
+			name:     "github",
+			cfg:      config.Issues{Provider: "github", Repo: "n3sty/focus"},
+			key:      "123",
+			body:     `{"number":123,"title":"Crash on start","html_url":"https://github.com/n3sty/focus/issues/123"}`,
+			wantPath: "/repos/n3sty/focus/issues/123",
+			wantAuth: "token s3cret",
+			want:     Issue{Key: "123", Title: "Crash on start", URL: "https://github.com/n3sty/focus/issues/123"},
+		},
+		{
+			name:     "gitlab",
+			cfg:      config.Issues{Provider: "gitlab", Repo: "group/sub/project"},
+			key:      "7",
+			body:     `{"iid":7,"title":"Slow dashboard","web_url":"https://gitlab.com/group/sub/project/-/issues/7"}`,
+			wantPath: "/api/v4/projects/group%2Fsub%2Fproject/issues/7",
+			wantAuth: "Bearer s3cret",
+			want:     Issue{Key: "7", Title: "Slow dashboard", URL: "https://gitlab.com/group/sub/project/-/issues/7"},
+		},
+		{
+			name:     "jira",
+			cfg:      config.Issues{Provider: "jira"},
+			key:      "PAY-88",
+			body:     `{"key":"PAY-88","fields":{"summary":"Refund rounding","status":{"name":"Open"}}}`,
+			wantPath: "/rest/api/2/issue/PAY-88",
+			wantQry:  "fields=summary",
+			wantAuth: "Bearer s3cret",
+			want:     Issue{Key: "PAY-88", Title: "Refund rounding", URL: "/browse/PAY-88"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, seen := tracker(t, http.StatusOK, tt.body)
+			t.Setenv("FOCUS_TEST_ISSUE_TOKEN", "s3cret")
+
+			cfg := tt.cfg
+			cfg.BaseURL = server.URL + "/" // Trailing slash is trimmed
+			cfg.TokenEnv = "FOCUS_TEST_ISSUE_TOKEN"
+			p, err := FromConfig(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := p.Fetch(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if seen.path != tt.wantPath || seen.query != tt.wantQry {
+				t.Errorf("requested %s?%s, want %s?%s", seen.path, seen.query, tt.wantPath, tt.wantQry)
+			}
+			if seen.auth != tt.wantAuth || seen.accept != "application/json" {
+				t.Errorf("Authorization = %q, Accept = %q; want %q and application/json", seen.auth, seen.accept, tt.wantAuth)
+			}
+
+			want := tt.want
+			if tt.name == "jira" {
+				want.URL = server.URL + want.URL
+			}
+			if *got != want {
+				t.Errorf("Fetch = %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
+
+func TestFetchWithoutToken(t *testing.T) {
+	server, seen := tracker(t, http.StatusOK, `{"title":"Public issue"}`)
+
+	p, err := FromConfig(config.Issues{Provider: "github", Repo: "o/r", BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Fetch("1"); err != nil {
+		t.Fatal(err)
+	}
+	if seen.auth != "" {
+		t.Errorf("Authorization = %q, want none without token_env", seen.auth)
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	server, _ := tracker(t, http.StatusNotFound, `{"message":"Not Found"}`)
+	github := GitHub{BaseURL: server.URL, Repo: "o/r"}
+
+	_, err := github.Fetch("404")
+	if err == nil || !strings.Contains(err.Error(), `404 Not Found: {"message":"Not Found"}`) {
+		t.Errorf("err = %v, want the status and response body", err)
+	}
+
+	// Keys of the wrong shape never reach the tracker
+	tests := []struct {
+		p   Provider
+		key string
+	}{
+		{github, "ABC-1"},
+		{GitLab{BaseURL: server.URL, Repo: "g/p"}, "ABC-1"},
+		{Jira{BaseURL: server.URL}, "123"},
+	}
+	for _, tt := range tests {
+		if _, err := tt.p.Fetch(tt.key); err == nil {
+			t.Errorf("%T.Fetch(%q) succeeded", tt.p, tt.key)
+		}
+	}
+
+	bad, _ := tracker(t, http.StatusOK, `not json`)
+	if _, err := (GitHub{BaseURL: bad.URL, Repo: "o/r"}).Fetch("1"); err == nil {
+		t.Error("Fetch accepted a body that isn't JSON")
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	tests := []struct {
+		cfg     config.Issues
+		want    string // Provider type, or "" for none
+		wantErr string
+	}{
+		{config.Issues{}, "", ""},
+		{config.Issues{Provider: "github", Repo: "o/r"}, "issue.GitHub", ""},
+		{config.Issues{Provider: "github"}, "", "issues.repo"},
+		{config.Issues{Provider: "gitlab", Repo: "g/p"}, "issue.GitLab", ""},
+		{config.Issues{Provider: "gitlab"}, "", "issues.repo"},
+		{config.Issues{Provider: "jira", BaseURL: "https://acme.atlassian.net"}, "issue.Jira", ""},
+		{config.Issues{Provider: "jira"}, "", "issues.base_url"},
+		{config.Issues{Provider: "trello"}, "", "unknown issues.provider"},
+	}
+
+	for _, tt := range tests {
+		p, err := FromConfig(tt.cfg)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("FromConfig(%+v) err = %v, want %q", tt.cfg, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("FromConfig(%+v): %v", tt.cfg, err)
+			continue
+		}
+
+		got := ""
+		if p != nil {
+			got = fmt.Sprintf("%T", p)
+		}
+		if got != tt.want {
+			t.Errorf("FromConfig(%+v) = %s, want %s", tt.cfg, got, tt.want)
+		}
+	}
+
+	if gh, _ := FromConfig(config.Issues{Provider: "github", Repo: "o/r"}); gh.(GitHub).BaseURL != "https://api.github.com" {
+		t.Errorf("github base URL = %q", gh.(GitHub).BaseURL)
+	}
+	if gl, _ := FromConfig(config.Issues{Provider: "gitlab", Repo: "g/p"}); gl.(GitLab).BaseURL != "https://gitlab.com" {
+		t.Errorf("gitlab base URL = %q", gl.(GitLab).BaseURL)
+	}
+}
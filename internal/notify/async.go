@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrQueueFull is returned by Async.Publish when too many events are
+// already waiting
+var ErrQueueFull = errors.New("webhook publish queue is full")
+
+// Async hands events to a Publisher on a background goroutine, so an
+// endpoint that is slow or down doesn't hold up the caller. Up to size
+// events wait their turn; beyond that Publish fails instead of blocking.
+type Async struct {
+	publisher Publisher
+	events    chan Event
+	flush     chan struct{}
+	quit      chan struct{}
+	done      chan struct{}
+}
+
+// NewAsync starts publishing to p in the background. Call Close to stop.
+func NewAsync(p Publisher, size int) *Async {
+	a := &Async{
+		publisher: p,
+		events:    make(chan Event, size),
+		flush:     make(chan struct{}, 1),
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Publish queues e for delivery and returns straight away
+func (a *Async) Publish(e Event) error {
+	select {
+	case <-a.quit:
+		return errors.New("webhook publisher is closed")
+	default:
+	}
+
+	select {
+	case a.events <- e:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Flush asks for queued deliveries to be retried. Requests made while a
+// flush is already pending are merged into it.
+func (a *Async) Flush() error {
+	select {
+	case a.flush <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close stops accepting events and waits up to timeout for the ones
+// already accepted to go out
+func (a *Async) Close(timeout time.Duration) {
+	select {
+	case <-a.quit:
+	default:
+		close(a.quit)
+	}
+
+	select {
+	case <-a.done:
+	case <-time.After(timeout):
+	}
+}
+
+func (a *Async) run() {
+	defer close(a.done)
+
+	for {
+		select {
+		case e := <-a.events:
+			a.publisher.Publish(e)
+		case <-a.flush:
+			a.publisher.Flush()
+		case <-a.quit:
+			// Deliver what was asked for before Close, oldest first
+			select {
+			case <-a.flush:
+				a.publisher.Flush()
+			default:
+			}
+			for {
+				select {
+				case e := <-a.events:
+					a.publisher.Publish(e)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Command runs a user command for each notification. The notification is
+// passed in FOCUS_TITLE, FOCUS_MESSAGE and FOCUS_URGENCY.
+type Command struct {
+	Argv []string
+}
+
+// Notify runs the command
+func (c Command) Notify(n Notification) error {
+	n = Prepare(n, limitsCommand)
+
+	cmd := exec.Command(c.Argv[0], c.Argv[1:]...)
+	cmd.Env = append(os.Environ(),
+		"FOCUS_TITLE="+n.Title,
+		"FOCUS_MESSAGE="+n.Message,
+		"FOCUS_URGENCY="+n.Urgency.String(),
+	)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("notification command %s failed: %w", c.Argv[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,492 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// privateBus starts a dbus-daemon for the test and points gdbus at it.
+// Tests are skipped where dbus-daemon or gdbus aren't installed.
+func privateBus(t *testing.T) string {
+	t.Helper()
+
+	for _, tool := range []string{"dbus-daemon", "gdbus"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s not installed", tool)
+		}
+	}
+
+	socket := filepath.Join(t.TempDir(), "bus")
+	daemon := exec.Command("dbus-daemon", "--session", "--nofork", "--address=unix:path="+socket, "--print-address")
+	stdout, err := daemon.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := daemon.Start(); err != nil {
+		t.Skipf("dbus-daemon won't start: %v", err)
+	}
+	t.Cleanup(func() {
+		daemon.Process.Kill()
+		daemon.Wait()
+	})
+
+	addr, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Skipf("dbus-daemon printed no address: %v", err)
+	}
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", strings.TrimSpace(addr))
+	return socket
+}
+
+// fakeServer owns org.freedesktop.Notifications on a private bus. It
+// speaks just enough of the D-Bus wire protocol to answer Notify and emit
+// ActionInvoked.
+type fakeServer struct {
+	conn net.Conn
+
+	wmu    sync.Mutex // Serializes writes from the test and serve
+	serial uint32
+
+	mu       sync.Mutex
+	received []Notification
+	urgency  []byte
+	got      chan struct{}
+}
+
+func newFakeServer(t *testing.T, socket string) *fakeServer {
+	t.Helper()
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	// SASL EXTERNAL with our uid, hex encoded
+	uid := hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+	fmt.Fprintf(conn, "\x00AUTH EXTERNAL %s\r\n", uid)
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "OK") {
+		t.Fatalf("auth failed: %q %v", line, err)
+	}
+	fmt.Fprint(conn, "BEGIN\r\n")
+
+	s := &fakeServer{conn: conn, got: make(chan struct{}, 10)}
+	s.call("Hello", "", nil)
+	var body wire
+	body.str(dbusDest)
+	body.u32(4) // DBUS_NAME_FLAG_DO_NOT_QUEUE
+	requested := s.call("RequestName", "su", body.b)
+
+	// Wait for the name before anyone looks for it
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			t.Fatalf("reading RequestName reply: %v", err)
+		}
+		if msg.typ == 2 && msg.replySerial == requested {
+			break
+		}
+	}
+
+	go s.serve(r)
+	return s
+}
+
+// send writes a message with the next serial and returns the serial
+func (s *fakeServer) send(typ byte, fields []headerField, body []byte) uint32 {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	s.serial++
+	s.conn.Write(encodeMessage(typ, s.serial, fields, body))
+	return s.serial
+}
+
+// call sends a method call to the bus itself
+func (s *fakeServer) call(member, sig string, body []byte) uint32 {
+	fields := []headerField{
+		{1, "o", "/org/freedesktop/DBus"},
+		{2, "s", "org.freedesktop.DBus"},
+		{3, "s", member},
+		{6, "s", "org.freedesktop.DBus"},
+	}
+	if sig != "" {
+		fields = append(fields, headerField{8, "g", sig})
+	}
+	return s.send(1, fields, body)
+}
+
+// serve answers Notify calls, remembering what was shown
+func (s *fakeServer) serve(r *bufio.Reader) {
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			return
+		}
+		if msg.typ != 1 {
+			continue
+		}
+		if msg.member == "GetServerInformation" {
+			var reply wire
+			for _, v := range []string{"fake", "focus", "1.0", "1.2"} {
+				reply.str(v)
+			}
+			s.send(2, []headerField{
+				{5, "u", msg.serial},
+				{6, "s", msg.sender},
+				{8, "g", "ssss"},
+			}, reply.b)
+			continue
+		}
+		if msg.member != "Notify" {
+			// gdbus introspects before calling; make it fall back quickly
+			s.send(3, []headerField{
+				{4, "s", "org.freedesktop.DBus.Error.UnknownMethod"},
+				{5, "u", msg.serial},
+				{6, "s", msg.sender},
+			}, nil)
+			continue
+		}
+
+		// susssasa{sv}i: app, replaces id, icon, summary, body, actions, hints, timeout
+		d := reader{b: msg.body}
+		d.str()
+		d.u32()
+		d.str()
+		n := Notification{Title: d.str(), Message: d.str()}
+		actions := d.strs()
+		for i := 0; i+1 < len(actions); i += 2 {
+			n.Actions = append(n.Actions, Action{Key: actions[i], Label: actions[i+1]})
+		}
+		urgency := d.byteHint("urgency")
+
+		s.mu.Lock()
+		s.received = append(s.received, n)
+		s.urgency = append(s.urgency, urgency)
+		s.mu.Unlock()
+
+		var reply wire
+		reply.u32(uint32(len(s.received)))
+		s.send(2, []headerField{
+			{5, "u", msg.serial},
+			{6, "s", msg.sender},
+			{8, "g", "u"},
+		}, reply.b)
+		s.got <- struct{}{}
+	}
+}
+
+// click emits ActionInvoked as if the user pressed a button
+func (s *fakeServer) click(key string) {
+	var body wire
+	body.u32(1)
+	body.str(key)
+	s.send(4, []headerField{
+		{1, "o", dbusPath},
+		{2, "s", dbusDest},
+		{3, "s", "ActionInvoked"},
+		{8, "g", "us"},
+	}, body.b)
+}
+
+func TestDBusNotify(t *testing.T) {
+	server := newFakeServer(t, privateBus(t))
+
+	err := DBus{}.Notify(Notification{
+		Title:   "🎯 Focus Check",
+		Message: "Still on <b>'Fix & ship'</b>?\nRun 'focus check'",
+		Urgency: UrgencyCritical,
+		Actions: ReminderActions,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-server.got:
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification never reached the bus")
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	got := server.received[0]
+	if got.Title != "🎯 Focus Check" {
+		t.Errorf("title = %q", got.Title)
+	}
+	if want := "Still on &lt;b&gt;'Fix &amp; ship'&lt;/b&gt;? Run 'focus check'"; got.Message != want {
+		t.Errorf("message = %q, want %q", got.Message, want)
+	}
+	if len(got.Actions) != len(ReminderActions) || got.Actions[3] != ReminderActions[3] {
+		t.Errorf("actions = %+v", got.Actions)
+	}
+	if server.urgency[0] != byte(UrgencyCritical) {
+		t.Errorf("urgency = %d, want %d", server.urgency[0], UrgencyCritical)
+	}
+}
+
+func TestDefaultNotifier(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("D-Bus is only the default on Linux")
+	}
+	socket := privateBus(t)
+
+	if n := Default(); n != (Desktop{}) {
+		t.Errorf("Default() = %T without a notification server, want Desktop", n)
+	}
+	newFakeServer(t, socket)
+	if n := Default(); n != (DBus{}) {
+		t.Errorf("Default() = %T with a notification server, want DBus", n)
+	}
+}
+
+func TestDBusActions(t *testing.T) {
+	server := newFakeServer(t, privateBus(t))
+
+	actions, stop, err := DBus{}.listenActions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	// The monitor subscribes in the background; click until it hears
+	deadline := time.After(5 * time.Second)
+	for got := ""; got == ""; {
+		server.click("other-app.reply")
+		server.click(ActionSnooze)
+
+		select {
+		case got = <-actions:
+			if got != ActionSnooze {
+				t.Fatalf("got action %q, want %q", got, ActionSnooze)
+			}
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("no action reported")
+		}
+	}
+
+	// A click nobody reads must not keep the listener alive after stop
+	server.click(ActionExtend)
+	time.Sleep(100 * time.Millisecond)
+	stop()
+
+	select {
+	case key, ok := <-actions:
+		if ok {
+			t.Errorf("got %q after stop, want the channel closed", key)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener still running after stop")
+	}
+}
+
+// wire builds little-endian D-Bus values, keeping alignment relative to
+// the start of the buffer
+type wire struct {
+	b []byte
+}
+
+func (w *wire) align(n int) {
+	for len(w.b)%n != 0 {
+		w.b = append(w.b, 0)
+	}
+}
+
+func (w *wire) u32(v uint32) {
+	w.align(4)
+	w.b = binary.LittleEndian.AppendUint32(w.b, v)
+}
+
+func (w *wire) str(s string) {
+	w.u32(uint32(len(s)))
+	w.b = append(w.b, s...)
+	w.b = append(w.b, 0)
+}
+
+func (w *wire) sig(s string) {
+	w.b = append(w.b, byte(len(s)))
+	w.b = append(w.b, s...)
+	w.b = append(w.b, 0)
+}
+
+type headerField struct {
+	code byte
+	typ  string // "s", "o", "u" or "g"
+	val  any
+}
+
+// encodeMessage frames a message: fixed header, header fields, padding
+// to 8 bytes, then the body
+func encodeMessage(typ byte, serial uint32, fields []headerField, body []byte) []byte {
+	var w wire
+	w.b = append(w.b, 'l', typ, 0, 1)
+	w.u32(uint32(len(body)))
+	w.u32(serial)
+	w.u32(0) // Header field array length, filled in below
+	start := len(w.b)
+	for _, f := range fields {
+		w.align(8)
+		w.b = append(w.b, f.code)
+		w.sig(f.typ)
+		switch f.typ {
+		case "s", "o":
+			w.str(f.val.(string))
+		case "u":
+			w.u32(f.val.(uint32))
+		case "g":
+			w.sig(f.val.(string))
+		}
+	}
+	binary.LittleEndian.PutUint32(w.b[12:], uint32(len(w.b)-start))
+	w.align(8)
+	return append(w.b, body...)
+}
+
+type message struct {
+	typ         byte
+	serial      uint32
+	replySerial uint32
+	member      string
+	sender      string
+	body        []byte
+}
+
+// readMessage reads one little-endian message off the bus
+func readMessage(r *bufio.Reader) (message, error) {
+	fixed := make([]byte, 16)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return message{}, err
+	}
+	if fixed[0] != 'l' {
+		return message{}, fmt.Errorf("big-endian messages aren't supported")
+	}
+	bodyLen := binary.LittleEndian.Uint32(fixed[4:])
+	fieldsLen := binary.LittleEndian.Uint32(fixed[12:])
+
+	headerLen := 16 + int(fieldsLen)
+	padded := (headerLen + 7) &^ 7
+	rest := make([]byte, padded-16+int(bodyLen))
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return message{}, err
+	}
+
+	msg := message{
+		typ:    fixed[1],
+		serial: binary.LittleEndian.Uint32(fixed[8:]),
+		body:   rest[padded-16:],
+	}
+
+	// Header fields, decoded with offsets from the start of the message
+	d := reader{b: append(fixed, rest[:fieldsLen]...), pos: 16}
+	for d.pos < headerLen {
+		d.align(8)
+		code := d.b[d.pos]
+		d.pos++
+		switch typ := d.sig(); typ {
+		case "s", "o":
+			v := d.str()
+			switch code {
+			case 3:
+				msg.member = v
+			case 7:
+				msg.sender = v
+			}
+		case "u":
+			v := d.u32()
+			if code == 5 {
+				msg.replySerial = v
+			}
+		case "g":
+			d.sig()
+		default:
+			return msg, fmt.Errorf("unexpected header field type %q", typ)
+		}
+	}
+
+	return msg, nil
+}
+
+// reader decodes the few D-Bus types the tests need
+type reader struct {
+	b   []byte
+	pos int
+}
+
+func (d *reader) align(n int) {
+	d.pos = (d.pos + n - 1) / n * n
+}
+
+func (d *reader) u32() uint32 {
+	d.align(4)
+	v := binary.LittleEndian.Uint32(d.b[d.pos:])
+	d.pos += 4
+	return v
+}
+
+func (d *reader) str() string {
+	n := int(d.u32())
+	s := string(d.b[d.pos : d.pos+n])
+	d.pos += n + 1
+	return s
+}
+
+func (d *reader) sig() string {
+	n := int(d.b[d.pos])
+	s := string(d.b[d.pos+1 : d.pos+1+n])
+	d.pos += n + 2
+	return s
+}
+
+// strs reads an array of strings
+func (d *reader) strs() []string {
+	n := int(d.u32())
+	end := d.pos + n
+	var out []string
+	for d.pos < end {
+		out = append(out, d.str())
+	}
+	return out
+}
+
+// byteHint reads a{sv} and returns the byte value stored under key
+func (d *reader) byteHint(key string) byte {
+	n := int(d.u32())
+	d.align(8)
+	end := d.pos + n
+
+	var found byte
+	for d.pos < end {
+		d.align(8)
+		k := d.str()
+		switch d.sig() {
+		case "y":
+			if v := d.b[d.pos]; k == key {
+				found = v
+			}
+			d.pos++
+		case "s":
+			d.str()
+		case "u", "i", "b":
+			d.u32()
+		default:
+			// Unknown hint type; stop rather than misread the rest
+			return found
+		}
+	}
+	return found
+}
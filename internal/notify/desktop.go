@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// Desktop sends notifications with the platform's command line tools:
+// osascript on macOS and notify-send on Linux
+type Desktop struct{}
+
+// Notify sends a desktop notification
+func (Desktop) Notify(n Notification) error {
+	switch runtime.GOOS {
+	case "darwin":
+		n = Prepare(n, limitsMacOS)
+		if n.Urgency == UrgencyCritical {
+			return sendMacOSWithSound(n.Title, n.Message, "Crystal")
+		}
+		return sendMacOS(n.Title, n.Message)
+	case "linux":
+		n = Prepare(n, limitsLinux)
+		return sendLinux(n.Title, n.Message, n.Urgency)
+	default:
+		return fmt.Errorf("notifications not supported on %s", runtime.GOOS)
+	}
+}
+
+// sendMacOS sends notification on macOS using osascript
+func sendMacOS(title, message string) error {
+	cmd := exec.Command("osascript", "-e", appleScript(title, message, ""))
+	return cmd.Run()
+}
+
+// sendMacOSWithSound sends notification with sound on macOS
+func sendMacOSWithSound(title, message, sound string) error {
+	cmd := exec.Command("osascript", "-e", appleScript(title, message, sound))
+	return cmd.Run()
+}
+
+// appleScript builds the display notification command, quoting every
+// value so task text can't end the string and run its own script
+func appleScript(title, message, sound string) string {
+	script := fmt.Sprintf(`display notification %s with title %s`,
+		appleScriptString(message), appleScriptString(title))
+	if sound != "" {
+		script += " sound name " + appleScriptString(sound)
+	}
+	return script
+}
+
+// sendLinux sends notification on Linux using notify-send
+func sendLinux(title, message string, urgency Urgency) error {
+	cmd := exec.Command("notify-send", notifySendArgs(title, message, urgency)...)
+	return cmd.Run()
+}
+
+// notifySendArgs builds the notify-send arguments. "--" stops a title
+// starting with "-" being read as a flag.
+func notifySendArgs(title, message string, urgency Urgency) []string {
+	return []string{"--urgency=" + urgency.String(), "--", title, message}
+}
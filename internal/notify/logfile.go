@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LogFile appends notifications to a file, one per line
+type LogFile struct {
+	Path string
+}
+
+// Notify appends the notification to the log
+func (l LogFile) Notify(n Notification) error {
+	// One line per entry, even if a task contains newlines
+	n = Prepare(n, limitsLog)
+
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s [%s] %s: %s\n", time.Now().Format(time.RFC3339), n.Urgency, n.Title, n.Message)
+	return err
+}
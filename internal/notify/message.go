@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Limits describes what a backend can safely display
+type Limits struct {
+	Title   int  // Max runes in the title
+	Message int  // Max runes in the message
+	Markup  bool // Message is parsed as Pango markup and must be escaped
+}
+
+// Per-backend limits. Notification centres cut long text anyway; staying
+// under their limits keeps the end of the message readable.
+var (
+	limitsMacOS    = Limits{Title: 60, Message: 200}
+	limitsLinux    = Limits{Title: 80, Message: 300, Markup: true}
+	limitsTerminal = Limits{Title: 60, Message: 120}
+	limitsLog      = Limits{Title: 200, Message: 1000}
+	limitsCommand  = Limits{Title: 200, Message: 1000}
+)
+
+const ellipsis = "…"
+
+// Prepare returns a copy of n that is safe to hand to a backend with the
+// given limits: control characters removed, whitespace collapsed, text
+// truncated and, where needed, markup escaped. Task names and drift notes
+// are user input, so every backend runs notifications through this first.
+func Prepare(n Notification, l Limits) Notification {
+	n.Title = Truncate(Sanitize(n.Title), l.Title)
+	n.Message = Truncate(Sanitize(n.Message), l.Message)
+	if l.Markup {
+		n.Message = escapeMarkup(n.Message)
+	}
+
+	actions := make([]Action, len(n.Actions))
+	for i, a := range n.Actions {
+		actions[i] = Action{Key: Sanitize(a.Key), Label: Truncate(Sanitize(a.Label), 30)}
+	}
+	n.Actions = actions
+
+	return n
+}
+
+// Sanitize replaces control characters (including newlines, escape
+// sequences and bidi overrides) with spaces and collapses runs of whitespace
+func Sanitize(s string) string {
+	var b strings.Builder
+	space := false
+
+	for _, r := range s {
+		if unicode.IsControl(r) || unicode.IsSpace(r) || unicode.Is(unicode.Bidi_Control, r) || r == unicode.ReplacementChar {
+			space = true
+			continue
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// Truncate shortens s to at most max runes, ending in an ellipsis
+func Truncate(s string, max int) string {
+	if max <= 0 {
+		return s
+	}
+
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	if max == 1 {
+		return ellipsis
+	}
+	return strings.TrimSpace(string(runes[:max-1])) + ellipsis
+}
+
+// escapeMarkup escapes text for Pango markup used by Linux notifications
+func escapeMarkup(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
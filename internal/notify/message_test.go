@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Fix the parser", "Fix the parser"},
+		{"newlines", "line one\nline two\r\nline three", "line one line two line three"},
+		{"tabs and runs of spaces", "a\t\t b   c", "a b c"},
+		{"leading and trailing space", "  \n task \t", "task"},
+		{"NUL and bell", "a\x00b\x07c", "a b c"},
+		{"terminal escape", "\x1b[31mred\x1b[0m", "[31mred [0m"},
+		{"OSC title escape", "\x1b]0;pwned\x07task", "]0;pwned task"},
+		{"bidi override", "evil\u202Etxt.exe", "evil txt.exe"},
+		{"invalid UTF-8", "ok\xffok", "ok ok"},
+		{"emoji kept", "🎯 Focus", "🎯 Focus"},
+		{"only control characters", "\x00\x01\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.in); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"short enough", "hello", 5, "hello"},
+		{"cut with ellipsis", "hello world", 8, "hello w…"},
+		{"trailing space trimmed", "hello world", 7, "hello…"},
+		{"no limit", "hello", 0, "hello"},
+		{"limit of one", "hello", 1, "…"},
+		{"multi-byte runes", "héllo wörld", 6, "héllo…"},
+		{"emoji not split", "🎯🎯🎯🎯", 3, "🎯🎯…"},
+		{"CJK", "集中して作業する", 4, "集中し…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Truncate(tt.in, tt.max)
+			if got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("Truncate(%q, %d) split a rune: %q", tt.in, tt.max, got)
+			}
+			if tt.max > 0 && utf8.RuneCountInString(got) > tt.max {
+				t.Errorf("Truncate(%q, %d) = %q is too long", tt.in, tt.max, got)
+			}
+		})
+	}
+}
+
+func TestEscapeMarkup(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"<b>bold</b>", "&lt;b&gt;bold&lt;/b&gt;"},
+		{"Tom & Jerry", "Tom &amp; Jerry"},
+		{"&lt; already escaped", "&amp;lt; already escaped"},
+		{`<a href="http://evil">click</a>`, `&lt;a href="http://evil"&gt;click&lt;/a&gt;`},
+		{"<span font='99'>", "&lt;span font='99'&gt;"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeMarkup(tt.in); got != tt.want {
+			t.Errorf("escapeMarkup(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAppleScriptString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`Fix "quoted" bug`, `"Fix \"quoted\" bug"`},
+		{`C:\path\`, `"C:\\path\\"`},
+		{`\"`, `"\\\""`},
+		{`" & do shell script "rm -rf ~" & "`, `"\" & do shell script \"rm -rf ~\" & \""`},
+		{"plain", `"plain"`},
+	}
+
+	for _, tt := range tests {
+		if got := appleScriptString(tt.in); got != tt.want {
+			t.Errorf("appleScriptString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAppleScriptInjection(t *testing.T) {
+	// The task can close the string and append its own command
+	task := `x" & (do shell script "touch /tmp/pwned") & "`
+	script := appleScript("🎯 Focus Check", "Still on "+task+"?", "Crystal")
+
+	// Every quote in the script must be a delimiter we put there or escaped
+	unescaped := 0
+	for i, r := range script {
+		if r == '"' && (i == 0 || script[i-1] != '\\') {
+			unescaped++
+		}
+	}
+	if unescaped != 6 {
+		t.Errorf("script has %d unescaped quotes, want 6 (three literals): %s", unescaped, script)
+	}
+	if !strings.HasSuffix(script, `sound name "Crystal"`) {
+		t.Errorf("script = %s, want the sound at the end", script)
+	}
+}
+
+func TestNotifySendArgs(t *testing.T) {
+	tests := []struct {
+		title, message string
+	}{
+		{"--help", "-u critical"},
+		{"-a evil", "--icon=/etc/passwd"},
+		{"🎯 Focus Check", "normal text"},
+	}
+
+	for _, tt := range tests {
+		args := notifySendArgs(tt.title, tt.message, UrgencyCritical)
+		want := []string{"--urgency=critical", "--", tt.title, tt.message}
+		if strings.Join(args, "\x00") != strings.Join(want, "\x00") {
+			t.Errorf("notifySendArgs(%q, %q) = %q, want %q", tt.title, tt.message, args, want)
+		}
+	}
+}
+
+func TestGVariantString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", `'plain'`},
+		{"it's", `'it\'s'`},
+		{`back\slash`, `'back\\slash'`},
+		{`\'`, `'\\\''`},
+		{`', 'injected`, `'\', \'injected'`},
+		{"", `''`},
+	}
+
+	for _, tt := range tests {
+		if got := gvariantString(tt.in); got != tt.want {
+			t.Errorf("gvariantString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	n := Prepare(Notification{
+		Title:   "-Focus\n<b>Check</b>",
+		Message: strings.Repeat("é", 400) + "\x1b[2J",
+		Actions: []Action{{Key: "focus.snooze\n", Label: strings.Repeat("x", 50)}},
+	}, limitsLinux)
+
+	if n.Title != "-Focus <b>Check</b>" {
+		t.Errorf("title = %q; titles aren't markup and keep their text", n.Title)
+	}
+	if utf8.RuneCountInString(n.Message) != limitsLinux.Message || !strings.HasSuffix(n.Message, ellipsis) {
+		t.Errorf("message has %d runes, want %d ending in an ellipsis", utf8.RuneCountInString(n.Message), limitsLinux.Message)
+	}
+	if n.Actions[0].Key != "focus.snooze" || utf8.RuneCountInString(n.Actions[0].Label) != 30 {
+		t.Errorf("action = %+v", n.Actions[0])
+	}
+}
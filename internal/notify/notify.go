@@ -1,51 +1,152 @@
 package notify
 
 import (
+	"errors"
 	"fmt"
-	"os/exec"
 	"runtime"
+
+	"github.com/n3sty/focus/internal/config"
+)
+
+// Urgency tells backends how loudly to deliver a notification
+type Urgency int
+
+const (
+	UrgencyLow Urgency = iota
+	UrgencyNormal
+	UrgencyCritical
 )
 
-// Send sends a desktop notification
-func Send(title, message string) error {
-	switch runtime.GOOS {
-	case "darwin":
-		return sendMacOS(title, message)
-	case "linux":
-		return sendLinux(title, message)
+// String returns the urgency name used in logs and hook environments
+func (u Urgency) String() string {
+	switch u {
+	case UrgencyLow:
+		return "low"
+	case UrgencyCritical:
+		return "critical"
 	default:
-		return fmt.Errorf("notifications not supported on %s", runtime.GOOS)
+		return "normal"
 	}
 }
 
-// SendUrgent sends an urgent notification with sound
-func SendUrgent(title, message string) error {
-	switch runtime.GOOS {
-	case "darwin":
-		return sendMacOSWithSound(title, message, "Crystal")
-	case "linux":
-		return sendLinux(title, message)
-	default:
-		return fmt.Errorf("notifications not supported on %s", runtime.GOOS)
+// Notification is a message for the user
+type Notification struct {
+	Title   string
+	Message string
+	Urgency Urgency
+	Actions []Action // Buttons, shown by backends that support them
+}
+
+// Action is a button on a notification
+type Action struct {
+	Key   string // Reported back when the button is clicked
+	Label string
+}
+
+// Action keys for buttons on focus reminders
+const (
+	ActionOnTrack = "focus.on-track"
+	ActionDrift   = "focus.drift"
+	ActionExtend  = "focus.extend"
+	ActionSnooze  = "focus.snooze"
+)
+
+// ReminderActions are the buttons offered on check and timebox reminders
+var ReminderActions = []Action{
+	{Key: ActionOnTrack, Label: "On track"},
+	{Key: ActionDrift, Label: "I drifted"},
+	{Key: ActionExtend, Label: "Extend 15m"},
+	{Key: ActionSnooze, Label: "Snooze"},
+}
+
+// Notifier delivers notifications to one backend
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+// Multi fans a notification out to several notifiers
+type Multi []Notifier
+
+// Notify sends to every notifier, even if some fail
+func (m Multi) Notify(n Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(n); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
 }
 
-// sendMacOS sends notification on macOS using osascript
-func sendMacOS(title, message string) error {
-	script := fmt.Sprintf(`display notification "%s" with title "%s"`, message, title)
-	cmd := exec.Command("osascript", "-e", script)
-	return cmd.Run()
+// ListenActions starts listening for button clicks on notifications sent
+// by n. Keys of clicked actions arrive on the returned channel until stop
+// is called. Notifiers without buttons return a nil channel.
+func ListenActions(n Notifier) (<-chan string, func(), error) {
+	switch n := n.(type) {
+	case DBus:
+		return n.listenActions()
+	case Multi:
+		for _, notifier := range n {
+			actions, stop, err := ListenActions(notifier)
+			if err != nil || actions != nil {
+				return actions, stop, err
+			}
+		}
+	}
+	return nil, func() {}, nil
 }
 
-// sendMacOSWithSound sends notification with sound on macOS
-func sendMacOSWithSound(title, message, sound string) error {
-	script := fmt.Sprintf(`display notification "%s" with title "%s" sound name "%s"`, message, title, sound)
-	cmd := exec.Command("osascript", "-e", script)
-	return cmd.Run()
+// Default is the notifier used when none is configured: D-Bus on Linux
+// while a notification server is running, so reminders get buttons, and
+// the desktop tools otherwise
+func Default() Notifier {
+	if runtime.GOOS == "linux" && (DBus{}).available() {
+		return DBus{}
+	}
+	return Desktop{}
 }
 
-// sendLinux sends notification on Linux using notify-send
-func sendLinux(title, message string) error {
-	cmd := exec.Command("notify-send", title, message)
-	return cmd.Run()
+// FromConfig builds the notifiers listed in the config file. With none
+// configured, it returns Default.
+func FromConfig(cfgs []config.Notifier) (Notifier, error) {
+	if len(cfgs) == 0 {
+		return Default(), nil
+	}
+
+	var multi Multi
+	for i, cfg := range cfgs {
+		notifier, err := fromConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("notifiers[%d]: %w", i, err)
+		}
+		multi = append(multi, notifier)
+	}
+
+	if len(multi) == 1 {
+		return multi[0], nil
+	}
+	return multi, nil
+}
+
+func fromConfig(cfg config.Notifier) (Notifier, error) {
+	switch cfg.Type {
+	case "desktop":
+		return Desktop{}, nil
+	case "dbus":
+		return DBus{}, nil
+	case "terminal":
+		return Terminal{Path: cfg.Path, Style: cfg.Style}, nil
+	case "log":
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("log notifier needs a path")
+		}
+		return LogFile{Path: cfg.Path}, nil
+	case "command":
+		if len(cfg.Command) == 0 {
+			return nil, fmt.Errorf("command notifier needs a command")
+		}
+		return Command{Argv: cfg.Command}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type %q", cfg.Type)
+	}
 }
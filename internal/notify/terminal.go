@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+)
+
+// Terminal rings the terminal bell and/or sends an OSC 9 escape sequence,
+// which terminals like iTerm2, kitty and Windows Terminal show as a
+// notification
+type Terminal struct {
+	Path  string // Terminal device, defaults to /dev/tty
+	Style string // "bell", "osc9" or "both" (default)
+}
+
+// Notify writes the escape sequences to the terminal
+func (t Terminal) Notify(n Notification) error {
+	path := t.Path
+	if path == "" {
+		path = "/dev/tty"
+	}
+
+	tty, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open terminal: %w", err)
+	}
+	defer tty.Close()
+
+	// Sanitizing also strips BEL and ESC, which would end the OSC sequence early
+	n = Prepare(n, limitsTerminal)
+
+	var seq string
+	if t.Style != "bell" {
+		seq += fmt.Sprintf("\x1b]9;%s: %s\x07", n.Title, n.Message)
+	}
+	if t.Style != "osc9" {
+		seq += "\a"
+	}
+
+	_, err = tty.WriteString(seq)
+	return err
+}
@@ -0,0 +1,391 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/n3sty/focus/internal/config"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/session"
+)
+
+const webhookQueueFile = ".focus/webhook-queue.jsonl"
+
+// EventType names a session lifecycle event; webhooks share the event
+// log's names
+type EventType = events.Type
+
+// Lifecycle events sent to webhooks
+const (
+	EventStarted  = events.SessionStarted
+	EventPaused   = events.SessionPaused
+	EventResumed  = events.SessionResumed
+	EventExtended = events.SessionExtended
+	EventEnded    = events.SessionEnded
+)
+
+// Event tells teammates what happened to a focus session
+type Event struct {
+	Type    EventType `json:"type"`
+	Time    time.Time `json:"time"`
+	User    string    `json:"user,omitempty"`
+	Task    string    `json:"task"`
+	Branch  string    `json:"branch"`
+	TimeBox string    `json:"timebox"`
+	Issue   string    `json:"issue,omitempty"`   // "#123" or "ABC-123"
+	Outcome string    `json:"outcome,omitempty"` // Ended sessions: "completed" or "abandoned"
+}
+
+// NewEvent describes sess for a lifecycle event
+func NewEvent(t EventType, sess *session.Session) Event {
+	e := Event{
+		Type:    t,
+		Time:    time.Now(),
+		User:    git.GetUserName(),
+		Task:    sess.Task,
+		Branch:  sess.Branch,
+		TimeBox: sess.TimeBox,
+	}
+	if sess.Issue != nil {
+		e.Issue = sess.Issue.Ref()
+	}
+	return e
+}
+
+// Publisher delivers lifecycle events
+type Publisher interface {
+	Publish(e Event) error
+	Flush() error // Retry events queued while offline
+}
+
+// Webhook posts events as JSON to a URL
+type Webhook struct {
+	URL     string
+	Format  string      // "json" (default), "slack" or "mattermost"
+	Events  []EventType // Events to send; empty means all
+	Retries int         // Extra attempts after the first, with exponential backoff
+	Backoff time.Duration
+	Client  *http.Client
+}
+
+// Webhooks publishes to several webhooks, queueing failed deliveries in
+// .focus/webhook-queue.jsonl until the next publish or flush
+type Webhooks []Webhook
+
+// queuedEvent is a delivery that failed and waits for a retry
+type queuedEvent struct {
+	URL     string          `json:"url"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// WebhooksFromConfig builds webhooks from the config file
+func WebhooksFromConfig(cfgs []config.Webhook) (Webhooks, error) {
+	var hooks Webhooks
+	for i, cfg := range cfgs {
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("webhooks[%d]: url is required", i)
+		}
+
+		switch cfg.Format {
+		case "", "json", "slack", "mattermost":
+		default:
+			return nil, fmt.Errorf("webhooks[%d]: unknown format %q", i, cfg.Format)
+		}
+
+		retries := 3
+		if cfg.Retries != nil {
+			retries = *cfg.Retries
+		}
+
+		hook := Webhook{
+			URL:     cfg.URL,
+			Format:  cfg.Format,
+			Retries: retries,
+			Backoff: 500 * time.Millisecond,
+			Client:  &http.Client{Timeout: 5 * time.Second},
+		}
+		for _, e := range cfg.Events {
+			hook.Events = append(hook.Events, EventType(e))
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
+// Publish sends e to every webhook that wants it. Deliveries that still
+// fail after retries are queued rather than lost.
+func (hooks Webhooks) Publish(e Event) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	// Older events go out first
+	flushErr := hooks.Flush()
+
+	var failed []queuedEvent
+	for _, hook := range hooks {
+		if !hook.wants(e.Type) {
+			continue
+		}
+
+		payload, err := hook.payload(e)
+		if err != nil {
+			return err
+		}
+
+		if err := hook.post(payload); err != nil {
+			failed = append(failed, queuedEvent{URL: hook.URL, Payload: payload})
+		}
+	}
+
+	if len(failed) > 0 {
+		if err := appendQueue(failed); err != nil {
+			return err
+		}
+		return fmt.Errorf("%d webhook deliveries failed and were queued", len(failed))
+	}
+
+	return flushErr
+}
+
+// Enqueue adds e to the queue file for every webhook that wants it,
+// without posting. The watcher sends it on its next flush, so commands
+// don't wait on a slow endpoint.
+func (hooks Webhooks) Enqueue(e Event) error {
+	var queued []queuedEvent
+	for _, hook := range hooks {
+		if !hook.wants(e.Type) {
+			continue
+		}
+
+		payload, err := hook.payload(e)
+		if err != nil {
+			return err
+		}
+		queued = append(queued, queuedEvent{URL: hook.URL, Payload: payload})
+	}
+
+	if len(queued) == 0 {
+		return nil
+	}
+	return appendQueue(queued)
+}
+
+// Queued counts the deliveries waiting in the queue file
+func Queued() int {
+	queued, _ := readQueue()
+	return len(queued)
+}
+
+// Flush retries queued deliveries, keeping the ones that still fail
+func (hooks Webhooks) Flush() error {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	queued, err := readQueue()
+	if err != nil || len(queued) == 0 {
+		return err
+	}
+
+	byURL := make(map[string]Webhook)
+	for _, hook := range hooks {
+		byURL[hook.URL] = hook
+	}
+
+	var remaining []queuedEvent
+	for _, q := range queued {
+		hook, ok := byURL[q.URL]
+		if !ok {
+			// Webhook was removed from the config; drop its backlog
+			continue
+		}
+		// One attempt per flush; the next publish tries again
+		hook.Retries = 0
+		if err := hook.post(q.Payload); err != nil {
+			remaining = append(remaining, q)
+		}
+	}
+
+	// Commands may have queued more while these were posted
+	if latest, err := readQueue(); err == nil && len(latest) > len(queued) {
+		remaining = append(remaining, latest[len(queued):]...)
+	}
+
+	if err := writeQueue(remaining); err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		return fmt.Errorf("%d queued webhook deliveries still failing", len(remaining))
+	}
+	return nil
+}
+
+func (w Webhook) wants(t EventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// payload renders the event in the webhook's format
+func (w Webhook) payload(e Event) ([]byte, error) {
+	switch w.Format {
+	case "slack":
+		return json.Marshal(map[string]string{"text": chatText(e, "*")})
+	case "mattermost":
+		return json.Marshal(map[string]string{"text": chatText(e, "**"), "username": "focus"})
+	default:
+		return json.Marshal(e)
+	}
+}
+
+// chatText summarises an event for Slack (*bold*) or Mattermost (**bold**)
+func chatText(e Event, bold string) string {
+	who := Sanitize(e.User)
+	if who == "" {
+		who = "Someone"
+	}
+	task := bold + Truncate(Sanitize(e.Task), 120) + bold
+
+	switch e.Type {
+	case EventStarted:
+		return fmt.Sprintf("🎯 %s started focusing on %s for %s. Please hold non-urgent messages.", who, task, e.TimeBox)
+	case EventPaused:
+		return fmt.Sprintf("📌 %s paused %s and is available.", who, task)
+	case EventResumed:
+		return fmt.Sprintf("🎯 %s is back to focusing on %s.", who, task)
+	case EventExtended:
+		return fmt.Sprintf("⏱️ %s extended %s to %s.", who, task, e.TimeBox)
+	case EventEnded:
+		if e.Outcome == "completed" {
+			return fmt.Sprintf("✅ %s finished %s and is available.", who, task)
+		}
+		return fmt.Sprintf("🗑️ %s ended %s (%s) and is available.", who, task, e.Outcome)
+	default:
+		return fmt.Sprintf("%s: %s %s", e.Type, who, task)
+	}
+}
+
+// post sends the payload, retrying with exponential backoff
+func (w Webhook) post(payload []byte) error {
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	backoff := w.Backoff
+	var err error
+	for attempt := 0; attempt <= w.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		var resp *http.Response
+		resp, err = client.Post(w.URL, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 300 {
+			return nil
+		}
+		err = fmt.Errorf("webhook %s returned %s", w.URL, resp.Status)
+
+		// Client errors won't fix themselves
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			break
+		}
+	}
+	return err
+}
+
+func readQueue() ([]queuedEvent, error) {
+	f, err := os.Open(webhookQueueFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var queued []queuedEvent
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var q queuedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &q); err != nil {
+			continue
+		}
+		queued = append(queued, q)
+	}
+	return queued, scanner.Err()
+}
+
+func writeQueue(queued []queuedEvent) error {
+	if len(queued) == 0 {
+		err := os.Remove(webhookQueueFile)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	data, err := encodeQueue(queued)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(webhookQueueFile, data, 0644)
+}
+
+// appendQueue adds to the end of the queue file in a single write, so
+// commands and the watcher can add to it at the same time
+func appendQueue(queued []queuedEvent) error {
+	data, err := encodeQueue(queued)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(webhookQueueFile), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(webhookQueueFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// encodeQueue renders queued deliveries as JSON lines
+func encodeQueue(queued []queuedEvent) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, q := range queued {
+		line, err := json.Marshal(q)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
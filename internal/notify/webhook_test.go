@@ -0,0 +1,361 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/n3sty/focus/internal/config"
+)
+
+// stub is a webhook endpoint that can be taken down and brought back
+type stub struct {
+	*httptest.Server
+	status atomic.Int32 // Status to answer with
+
+	mu       sync.Mutex
+	attempts int
+	bodies   []string
+}
+
+func newStub(t *testing.T, status int) *stub {
+	t.Helper()
+
+	s := &stub{}
+	s.status.Store(int32(status))
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.mu.Lock()
+		s.attempts++
+		code := int(s.status.Load())
+		if code < 300 {
+			s.bodies = append(s.bodies, string(body))
+		}
+		s.mu.Unlock()
+
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *stub) counts() (attempts int, bodies []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts, append([]string(nil), s.bodies...)
+}
+
+func testEvent(t EventType, task string) Event {
+	return Event{
+		Type:    t,
+		Time:    time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		User:    "Ada",
+		Task:    task,
+		Branch:  "focus/parser",
+		TimeBox: "2h",
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int // Answers in order; the last one repeats
+		retries  int
+		wantErr  bool
+		attempts int
+	}{
+		{"first try", []int{200}, 3, false, 1},
+		{"recovers after server errors", []int{503, 502, 204}, 3, false, 3},
+		{"gives up after retries", []int{500}, 2, true, 3},
+		{"rate limited is retried", []int{429, 200}, 3, false, 2},
+		{"client error is not retried", []int{400}, 3, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(attempts.Add(1)) - 1
+				w.WriteHeader(tt.statuses[min(n, len(tt.statuses)-1)])
+			}))
+			defer server.Close()
+
+			hook := Webhook{URL: server.URL, Retries: tt.retries, Backoff: time.Millisecond}
+			err := hook.post([]byte(`{}`))
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error: %v", err, tt.wantErr)
+			}
+			if int(attempts.Load()) != tt.attempts {
+				t.Errorf("attempts = %d, want %d", attempts.Load(), tt.attempts)
+			}
+		})
+	}
+}
+
+func TestWebhookOfflineQueue(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	server := newStub(t, http.StatusServiceUnavailable)
+	hooks := Webhooks{{URL: server.URL, Retries: 1, Backoff: time.Millisecond}}
+
+	// Endpoint down: both events end up in the queue file
+	for _, task := range []string{"first", "second"} {
+		if err := hooks.Publish(testEvent(EventStarted, task)); err == nil {
+			t.Fatalf("publishing %q to a down endpoint succeeded", task)
+		}
+	}
+
+	queued, err := readQueue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 2 || queued[0].URL != server.URL {
+		t.Fatalf("queue = %+v, want two events for %s", queued, server.URL)
+	}
+
+	// Still down: flushing keeps them
+	if err := hooks.Flush(); err == nil {
+		t.Error("flush to a down endpoint succeeded")
+	}
+	if queued, _ := readQueue(); len(queued) != 2 {
+		t.Fatalf("queue after failed flush has %d events, want 2", len(queued))
+	}
+
+	// Back up: the queue drains in order and the file goes away
+	server.status.Store(http.StatusOK)
+	if err := hooks.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if _, err := os.Stat(webhookQueueFile); !os.IsNotExist(err) {
+		t.Errorf("queue file still there after flush: %v", err)
+	}
+
+	_, bodies := server.counts()
+	if len(bodies) != 2 {
+		t.Fatalf("delivered %d events, want 2", len(bodies))
+	}
+	for i, want := range []string{"first", "second"} {
+		var e Event
+		if err := json.Unmarshal([]byte(bodies[i]), &e); err != nil {
+			t.Fatal(err)
+		}
+		if e.Task != want || e.Type != EventStarted {
+			t.Errorf("delivery %d = %+v, want %q", i, e, want)
+		}
+	}
+}
+
+func TestWebhookPublishFlushesFirst(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	server := newStub(t, http.StatusBadGateway)
+	hooks := Webhooks{{URL: server.URL, Backoff: time.Millisecond}}
+
+	hooks.Publish(testEvent(EventStarted, "old"))
+	server.status.Store(http.StatusOK)
+	if err := hooks.Publish(testEvent(EventEnded, "new")); err != nil {
+		t.Fatal(err)
+	}
+
+	_, bodies := server.counts()
+	if len(bodies) != 2 || !strings.Contains(bodies[0], `"old"`) || !strings.Contains(bodies[1], `"new"`) {
+		t.Errorf("deliveries = %q, want old then new", bodies)
+	}
+}
+
+func TestWebhookEnqueue(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	server := newStub(t, http.StatusOK)
+	hooks := Webhooks{
+		{URL: server.URL},
+		{URL: server.URL + "/ended", Events: []EventType{EventEnded}},
+	}
+
+	if err := hooks.Enqueue(testEvent(EventStarted, "parser")); err != nil {
+		t.Fatal(err)
+	}
+	if attempts, _ := server.counts(); attempts != 0 {
+		t.Errorf("Enqueue posted %d times, want it left to the next flush", attempts)
+	}
+	if n := Queued(); n != 1 {
+		t.Fatalf("queued %d deliveries, want 1 for the webhook that wants started events", n)
+	}
+
+	if err := hooks.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, bodies := server.counts(); len(bodies) != 1 || !strings.Contains(bodies[0], `"parser"`) {
+		t.Errorf("deliveries = %q, want the queued event", bodies)
+	}
+	if n := Queued(); n != 0 {
+		t.Errorf("queued %d deliveries after flushing, want none", n)
+	}
+}
+
+func TestWebhookFlushKeepsEventsQueuedMeanwhile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	hooks := Webhooks{{URL: "placeholder"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A command queues another event while the flush is posting
+		if err := hooks.Enqueue(testEvent(EventEnded, "meanwhile")); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	hooks[0].URL = server.URL
+
+	if err := hooks.Enqueue(testEvent(EventStarted, "first")); err != nil {
+		t.Fatal(err)
+	}
+	hooks.Flush()
+
+	queued, err := readQueue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 2 || !strings.Contains(string(queued[1].Payload), `"meanwhile"`) {
+		t.Errorf("queue = %d events, want the failed one and the one queued during the flush", len(queued))
+	}
+}
+
+func TestWebhookDropsRemovedURLs(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if err := appendQueue([]queuedEvent{{URL: "http://gone.invalid", Payload: json.RawMessage(`{}`)}}); err != nil {
+		t.Fatal(err)
+	}
+
+	server := newStub(t, http.StatusOK)
+	if err := (Webhooks{{URL: server.URL}}).Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if queued, _ := readQueue(); len(queued) != 0 {
+		t.Errorf("queue = %+v, want events for removed webhooks dropped", queued)
+	}
+}
+
+func TestWebhookFormats(t *testing.T) {
+	e := testEvent(EventEnded, "Fix\nthe *parser*")
+	e.Outcome = "completed"
+
+	tests := []struct {
+		format string
+		want   map[string]string
+	}{
+		{"slack", map[string]string{"text": "✅ Ada finished *Fix the *parser** and is available."}},
+		{"mattermost", map[string]string{"text": "✅ Ada finished **Fix the *parser*** and is available.", "username": "focus"}},
+	}
+
+	for _, tt := range tests {
+		payload, err := Webhook{Format: tt.format}.payload(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]string
+		if err := json.Unmarshal(payload, &got); err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range tt.want {
+			if got[k] != v {
+				t.Errorf("%s %s = %q, want %q", tt.format, k, got[k], v)
+			}
+		}
+	}
+
+	payload, _ := Webhook{}.payload(e)
+	if !strings.Contains(string(payload), `"outcome":"completed"`) {
+		t.Errorf("json payload = %s", payload)
+	}
+}
+
+func TestWebhookEventFilter(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	server := newStub(t, http.StatusOK)
+	hooks, err := WebhooksFromConfig([]config.Webhook{{URL: server.URL, Events: []string{string(EventEnded)}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hooks.Publish(testEvent(EventStarted, "skipped"))
+	hooks.Publish(testEvent(EventEnded, "sent"))
+
+	if _, bodies := server.counts(); len(bodies) != 1 || !strings.Contains(bodies[0], `"sent"`) {
+		t.Errorf("deliveries = %q, want only the ended event", bodies)
+	}
+}
+
+// slowPublisher blocks every call until released
+type slowPublisher struct {
+	release   chan struct{}
+	published chan Event
+	flushes   atomic.Int32
+}
+
+func (p *slowPublisher) Publish(e Event) error {
+	<-p.release
+	p.published <- e
+	return nil
+}
+
+func (p *slowPublisher) Flush() error {
+	p.flushes.Add(1)
+	<-p.release
+	return nil
+}
+
+func TestAsyncDoesNotBlock(t *testing.T) {
+	slow := &slowPublisher{release: make(chan struct{}), published: make(chan Event, 10)}
+	async := NewAsync(slow, 2)
+
+	start := time.Now()
+	async.Flush()
+
+	// Wait for the worker to get stuck in the flush
+	for slow.flushes.Load() == 0 {
+		if time.Since(start) > time.Second {
+			t.Fatal("flush never started")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	async.Flush()
+	async.Flush() // Merged with the one above
+	for i := 0; i < 2; i++ {
+		if err := async.Publish(testEvent(EventStarted, "queued")); err != nil {
+			t.Fatalf("publish %d: %v", i, err)
+		}
+	}
+	if err := async.Publish(testEvent(EventStarted, "dropped")); err != ErrQueueFull {
+		t.Errorf("publish to a full queue = %v, want ErrQueueFull", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("callers waited %s on a stuck endpoint", elapsed)
+	}
+
+	// Unstick the endpoint; Close delivers what was accepted
+	close(slow.release)
+	async.Close(time.Second)
+
+	if len(slow.published) != 2 {
+		t.Errorf("published %d events, want 2", len(slow.published))
+	}
+	if n := slow.flushes.Load(); n != 2 {
+		t.Errorf("flushed %d times, want pending flushes merged and run before closing", n)
+	}
+	if err := async.Publish(testEvent(EventEnded, "late")); err == nil {
+		t.Error("publish after Close succeeded")
+	}
+}
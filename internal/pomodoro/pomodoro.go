@@ -0,0 +1,168 @@
+package pomodoro
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Plan describes a pomodoro cycle such as "25/5/15x4": 25 minutes of work,
+// 5 minute short breaks, and a 15 minute long break after every 4 rounds
+type Plan struct {
+	Work       time.Duration
+	ShortBreak time.Duration
+	LongBreak  time.Duration
+	Rounds     int // Work intervals before a long break
+}
+
+// DefaultPlan is the classic 25/5/15x4 cycle
+var DefaultPlan = Plan{
+	Work:       25 * time.Minute,
+	ShortBreak: 5 * time.Minute,
+	LongBreak:  15 * time.Minute,
+	Rounds:     4,
+}
+
+// Parse reads a plan spec in minutes: "work/short/longxrounds". Trailing
+// parts may be omitted ("50/10") and fall back to the default plan.
+func Parse(spec string) (Plan, error) {
+	plan := DefaultPlan
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return plan, nil
+	}
+
+	if i := strings.LastIndex(spec, "x"); i >= 0 {
+		rounds, err := strconv.Atoi(spec[i+1:])
+		if err != nil || rounds < 1 {
+			return Plan{}, fmt.Errorf("invalid pomodoro rounds in %q", spec)
+		}
+		plan.Rounds = rounds
+		spec = spec[:i]
+	}
+
+	targets := []*time.Duration{&plan.Work, &plan.ShortBreak, &plan.LongBreak}
+	parts := strings.Split(spec, "/")
+	if len(parts) > len(targets) {
+		return Plan{}, fmt.Errorf("invalid pomodoro plan %q (want work/short/longxrounds)", spec)
+	}
+
+	for i, part := range parts {
+		minutes, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || minutes < 1 {
+			return Plan{}, fmt.Errorf("invalid pomodoro minutes %q", part)
+		}
+		*targets[i] = time.Duration(minutes) * time.Minute
+	}
+
+	return plan, nil
+}
+
+// String formats the plan back into its spec
+func (p Plan) String() string {
+	return fmt.Sprintf("%d/%d/%dx%d",
+		int(p.Work.Minutes()), int(p.ShortBreak.Minutes()), int(p.LongBreak.Minutes()), p.Rounds)
+}
+
+// MarshalText stores the plan as its spec in session files
+func (p Plan) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText parses a plan spec from session files
+func (p *Plan) UnmarshalText(text []byte) error {
+	plan, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*p = plan
+	return nil
+}
+
+// Phase is the current part of the cycle
+type Phase string
+
+const (
+	PhaseWork       Phase = "work"
+	PhaseShortBreak Phase = "short_break"
+	PhaseLongBreak  Phase = "long_break"
+)
+
+// IsBreak reports whether the phase is a short or long break
+func (p Phase) IsBreak() bool {
+	return p == PhaseShortBreak || p == PhaseLongBreak
+}
+
+// Label returns a human readable phase name
+func (p Phase) Label() string {
+	switch p {
+	case PhaseShortBreak:
+		return "Short break"
+	case PhaseLongBreak:
+		return "Long break"
+	default:
+		return "Work"
+	}
+}
+
+// State tracks progress through a plan
+type State struct {
+	Plan       Plan      `json:"plan"`
+	Phase      Phase     `json:"phase"`
+	PhaseStart time.Time `json:"phase_start"`
+	Completed  int       `json:"completed"` // Finished work intervals
+}
+
+// New starts a plan with a work interval
+func New(plan Plan, start time.Time) *State {
+	return &State{
+		Plan:       plan,
+		Phase:      PhaseWork,
+		PhaseStart: start,
+	}
+}
+
+// PhaseLength returns how long the current phase lasts
+func (s *State) PhaseLength() time.Duration {
+	switch s.Phase {
+	case PhaseShortBreak:
+		return s.Plan.ShortBreak
+	case PhaseLongBreak:
+		return s.Plan.LongBreak
+	default:
+		return s.Plan.Work
+	}
+}
+
+// Remaining returns the time left in the current phase
+func (s *State) Remaining(now time.Time) time.Duration {
+	left := s.PhaseStart.Add(s.PhaseLength()).Sub(now)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// Advance moves to the next phase once the current one is over.
+// Returns true if the phase changed.
+func (s *State) Advance(now time.Time) bool {
+	if s.Remaining(now) > 0 {
+		return false
+	}
+
+	if s.Phase == PhaseWork {
+		s.Completed++
+		s.Phase = PhaseShortBreak
+		if s.Plan.Rounds > 0 && s.Completed%s.Plan.Rounds == 0 {
+			s.Phase = PhaseLongBreak
+		}
+	} else {
+		s.Phase = PhaseWork
+	}
+
+	// Restart from now rather than the scheduled end so a sleeping laptop
+	// doesn't replay missed phases one tick at a time
+	s.PhaseStart = now
+	return true
+}
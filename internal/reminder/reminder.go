@@ -0,0 +1,250 @@
+package reminder
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/clock"
+	"github.com/n3sty/focus/internal/config"
+)
+
+// Policy decides when the watcher reminds the user
+type Policy struct {
+	Interval   time.Duration   // Regular "focus check" reminders (0 disables)
+	Warnings   []time.Duration // Time left before expiry to warn at, e.g. 15m
+	Nags       []time.Duration // Gaps between post-expiry nags; the last one repeats
+	QuietHours *QuietHours     // No reminders during these hours
+}
+
+// DefaultPolicy returns sensible defaults
+func DefaultPolicy() Policy {
+	return Policy{
+		Interval: 25 * time.Minute,
+		Warnings: []time.Duration{15 * time.Minute},
+		Nags:     []time.Duration{10 * time.Minute, 20 * time.Minute, 30 * time.Minute},
+	}
+}
+
+// PolicyFrom overrides the defaults with settings from the config file
+func PolicyFrom(cfg config.Reminders) (Policy, error) {
+	policy := DefaultPolicy()
+
+	var err error
+	if policy.Interval, err = config.ParseDuration(cfg.Interval, policy.Interval); err != nil {
+		return policy, fmt.Errorf("reminders.interval: %w", err)
+	}
+	if cfg.Warnings != nil {
+		if policy.Warnings, err = parseDurations(cfg.Warnings); err != nil {
+			return policy, fmt.Errorf("reminders.warnings: %w", err)
+		}
+	}
+	if cfg.Nags != nil {
+		if policy.Nags, err = parseDurations(cfg.Nags); err != nil {
+			return policy, fmt.Errorf("reminders.nags: %w", err)
+		}
+	}
+	if cfg.QuietHours != nil {
+		quiet, err := ParseQuietHours(cfg.QuietHours.Start, cfg.QuietHours.End)
+		if err != nil {
+			return policy, fmt.Errorf("reminders.quiet_hours: %w", err)
+		}
+		policy.QuietHours = quiet
+	}
+
+	return policy, nil
+}
+
+func parseDurations(values []string) ([]time.Duration, error) {
+	durations := make([]time.Duration, 0, len(values))
+	for _, v := range values {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		durations = append(durations, d)
+	}
+	return durations, nil
+}
+
+// QuietHours is a daily window, possibly wrapping midnight (22:00-08:00)
+type QuietHours struct {
+	Start time.Duration // Offset from midnight
+	End   time.Duration
+}
+
+// ParseQuietHours reads "HH:MM" start and end times
+func ParseQuietHours(start, end string) (*QuietHours, error) {
+	s, err := parseClock(start)
+	if err != nil {
+		return nil, err
+	}
+	e, err := parseClock(end)
+	if err != nil {
+		return nil, err
+	}
+	return &QuietHours{Start: s, End: e}, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time %q (want HH:MM)", value)
+	}
+	h, err := strconv.Atoi(parts[0])
+	if err != nil || h < 0 || h > 23 {
+		return 0, fmt.Errorf("invalid hour in %q", value)
+	}
+	m, err := strconv.Atoi(parts[1])
+	if err != nil || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid minute in %q", value)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// Contains reports whether t falls inside the quiet window
+func (q *QuietHours) Contains(t time.Time) bool {
+	if q == nil || q.Start == q.End {
+		return false
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	if q.Start < q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End
+}
+
+// Kind identifies what a reminder is about
+type Kind int
+
+const (
+	KindCheck   Kind = iota // Periodic "still on track?"
+	KindWarning             // Timebox is about to run out
+	KindExpired             // Timebox just ran out
+	KindNag                 // Still going after expiry
+)
+
+// Reminder is a notification the watcher should send
+type Reminder struct {
+	Kind   Kind
+	Level  int           // Escalation level, 1 for the first expiry notice
+	Left   time.Duration // Time left in the timebox (warnings)
+	Over   time.Duration // Time past the timebox (expiry and nags)
+	Urgent bool
+}
+
+// Input is the session state the engine needs on each tick
+type Input struct {
+	Elapsed      time.Duration // Focused time so far
+	Timebox      time.Duration // 0 if the session has no timebox
+	SnoozedUntil time.Time     // Zero if not snoozed
+	LastCheckIn  time.Time     // Zero if the user hasn't checked in yet
+}
+
+// Engine applies a policy over time and remembers what it already sent
+type Engine struct {
+	policy Policy
+	clock  clock.Clock
+
+	lastReminder time.Time
+	warningsSent int
+	expired      bool
+	nags         int
+	lastNag      time.Time
+}
+
+// NewEngine creates an engine whose reminder interval starts now
+func NewEngine(policy Policy, c clock.Clock) *Engine {
+	// Warn at the largest remaining time first
+	warnings := append([]time.Duration(nil), policy.Warnings...)
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i] > warnings[j] })
+	policy.Warnings = warnings
+
+	return &Engine{
+		policy:       policy,
+		clock:        c,
+		lastReminder: c.Now(),
+	}
+}
+
+// Next returns the reminder due now, if any. At most one reminder is
+// returned per call, the most important first.
+func (e *Engine) Next(in Input) *Reminder {
+	now := e.clock.Now()
+
+	if now.Before(in.SnoozedUntil) || e.policy.QuietHours.Contains(now) {
+		return nil
+	}
+
+	if in.Timebox > 0 {
+		left := in.Timebox - in.Elapsed
+
+		if left <= 0 {
+			return e.afterExpiry(now, -left)
+		}
+
+		// The timebox was extended after it ran out - start over
+		if e.expired {
+			e.expired = false
+			e.nags = 0
+			e.warningsSent = 0
+		}
+
+		// The timebox grew before it ran out - re-arm the warnings it
+		// moved back out of reach
+		for e.warningsSent > 0 && left > e.policy.Warnings[e.warningsSent-1] {
+			e.warningsSent--
+		}
+
+		// Skip warnings that were crossed together (e.g. after a snooze)
+		due := false
+		for e.warningsSent < len(e.policy.Warnings) && left <= e.policy.Warnings[e.warningsSent] {
+			e.warningsSent++
+			due = true
+		}
+		if due {
+			return &Reminder{Kind: KindWarning, Left: left}
+		}
+	}
+
+	// A check-in restarts the interval just like a reminder does
+	since := e.lastReminder
+	if in.LastCheckIn.After(since) {
+		since = in.LastCheckIn
+	}
+	if e.policy.Interval > 0 && now.Sub(since) >= e.policy.Interval {
+		e.lastReminder = now
+		return &Reminder{Kind: KindCheck}
+	}
+
+	return nil
+}
+
+// afterExpiry sends the expiry notice once, then escalating nags
+func (e *Engine) afterExpiry(now time.Time, over time.Duration) *Reminder {
+	if !e.expired {
+		e.expired = true
+		e.lastNag = now
+		return &Reminder{Kind: KindExpired, Level: 1, Over: over, Urgent: true}
+	}
+
+	if len(e.policy.Nags) == 0 {
+		return nil
+	}
+
+	gap := e.policy.Nags[len(e.policy.Nags)-1]
+	if e.nags < len(e.policy.Nags) {
+		gap = e.policy.Nags[e.nags]
+	}
+
+	if now.Sub(e.lastNag) < gap {
+		return nil
+	}
+
+	e.nags++
+	e.lastNag = now
+	return &Reminder{Kind: KindNag, Level: e.nags + 1, Over: over, Urgent: true}
+}
@@ -0,0 +1,211 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/n3sty/focus/internal/clock"
+	"github.com/n3sty/focus/internal/config"
+)
+
+var start = time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+// kinds steps the clock a minute at a time from start and returns the
+// reminders sent at each minute mark, given the minutes elapsed
+func kinds(t *testing.T, policy Policy, timebox time.Duration, minutes int, input func(*Input)) map[int]Reminder {
+	t.Helper()
+
+	c := &clock.Fake{T: start}
+	e := NewEngine(policy, c)
+	sent := map[int]Reminder{}
+
+	for m := 1; m <= minutes; m++ {
+		c.Advance(time.Minute)
+		in := Input{Elapsed: c.Now().Sub(start), Timebox: timebox}
+		if input != nil {
+			input(&in)
+		}
+		if r := e.Next(in); r != nil {
+			sent[m] = *r
+		}
+	}
+	return sent
+}
+
+func TestCheckInterval(t *testing.T) {
+	policy := Policy{Interval: 25 * time.Minute}
+	sent := kinds(t, policy, 0, 60, nil)
+
+	if len(sent) != 2 || sent[25].Kind != KindCheck || sent[50].Kind != KindCheck {
+		t.Errorf("sent = %+v, want checks at 25 and 50", sent)
+	}
+}
+
+func TestCheckInRestartsInterval(t *testing.T) {
+	policy := Policy{Interval: 25 * time.Minute}
+	sent := kinds(t, policy, 0, 60, func(in *Input) {
+		in.LastCheckIn = start.Add(20 * time.Minute)
+	})
+
+	if len(sent) != 1 || sent[45].Kind != KindCheck {
+		t.Errorf("sent = %+v, want one check at 45", sent)
+	}
+}
+
+func TestEscalation(t *testing.T) {
+	policy := Policy{
+		Warnings: []time.Duration{5 * time.Minute, 15 * time.Minute},
+		Nags:     []time.Duration{10 * time.Minute, 20 * time.Minute},
+	}
+	sent := kinds(t, policy, time.Hour, 130, nil)
+
+	want := map[int]Reminder{
+		45:  {Kind: KindWarning, Left: 15 * time.Minute},
+		55:  {Kind: KindWarning, Left: 5 * time.Minute},
+		60:  {Kind: KindExpired, Level: 1, Urgent: true},
+		70:  {Kind: KindNag, Level: 2, Over: 10 * time.Minute, Urgent: true},
+		90:  {Kind: KindNag, Level: 3, Over: 30 * time.Minute, Urgent: true},
+		110: {Kind: KindNag, Level: 4, Over: 50 * time.Minute, Urgent: true},
+		130: {Kind: KindNag, Level: 5, Over: 70 * time.Minute, Urgent: true},
+	}
+	if len(sent) != len(want) {
+		t.Fatalf("sent %d reminders, want %d: %+v", len(sent), len(want), sent)
+	}
+	for m, w := range want {
+		if sent[m] != w {
+			t.Errorf("minute %d: got %+v, want %+v", m, sent[m], w)
+		}
+	}
+}
+
+func TestExtensionStartsOver(t *testing.T) {
+	c := &clock.Fake{T: start}
+	e := NewEngine(Policy{Warnings: []time.Duration{5 * time.Minute}}, c)
+
+	c.Advance(30 * time.Minute)
+	if r := e.Next(Input{Elapsed: 30 * time.Minute, Timebox: 30 * time.Minute}); r == nil || r.Kind != KindExpired {
+		t.Fatalf("got %+v, want expiry", r)
+	}
+
+	// Extended by 15 minutes: warn again before the new end
+	c.Advance(5 * time.Minute)
+	if r := e.Next(Input{Elapsed: 35 * time.Minute, Timebox: 45 * time.Minute}); r != nil {
+		t.Errorf("got %+v right after extending, want nothing", r)
+	}
+	c.Advance(5 * time.Minute)
+	if r := e.Next(Input{Elapsed: 40 * time.Minute, Timebox: 45 * time.Minute}); r == nil || r.Kind != KindWarning {
+		t.Errorf("got %+v, want a warning", r)
+	}
+	c.Advance(5 * time.Minute)
+	if r := e.Next(Input{Elapsed: 45 * time.Minute, Timebox: 45 * time.Minute}); r == nil || r.Kind != KindExpired {
+		t.Errorf("got %+v, want a second expiry", r)
+	}
+}
+
+func TestExtensionBeforeExpiryRearmsWarnings(t *testing.T) {
+	policy := Policy{Warnings: []time.Duration{15 * time.Minute, 5 * time.Minute}}
+	timebox := time.Hour
+	sent := kinds(t, policy, 0, 90, func(in *Input) {
+		// Extended by 15 minutes right after the first warning
+		if in.Elapsed > 46*time.Minute {
+			timebox = 75 * time.Minute
+		}
+		in.Timebox = timebox
+	})
+
+	if len(sent) != 4 || sent[45].Left != 15*time.Minute || sent[60].Left != 15*time.Minute || sent[70].Left != 5*time.Minute || sent[75].Kind != KindExpired {
+		t.Errorf("sent = %+v, want 15m at 45 and again at 60, 5m at 70, expiry at 75", sent)
+	}
+}
+
+func TestSnooze(t *testing.T) {
+	policy := Policy{
+		Warnings: []time.Duration{15 * time.Minute, 5 * time.Minute},
+		Nags:     []time.Duration{10 * time.Minute},
+	}
+	until := start.Add(57 * time.Minute)
+	sent := kinds(t, policy, time.Hour, 75, func(in *Input) {
+		in.SnoozedUntil = until
+	})
+
+	// Both warnings were crossed during the snooze and come out as one
+	want := map[int]Kind{57: KindWarning, 60: KindExpired, 70: KindNag}
+	if len(sent) != len(want) {
+		t.Fatalf("sent = %+v, want %v", sent, want)
+	}
+	for m, k := range want {
+		if sent[m].Kind != k {
+			t.Errorf("minute %d: got %+v, want kind %d", m, sent[m], k)
+		}
+	}
+	if sent[57].Left != 3*time.Minute {
+		t.Errorf("warning left = %s, want 3m", sent[57].Left)
+	}
+}
+
+func TestQuietHours(t *testing.T) {
+	quiet, err := ParseQuietHours("10:30", "11:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := Policy{Interval: 20 * time.Minute, Nags: []time.Duration{10 * time.Minute}, QuietHours: quiet}
+	sent := kinds(t, policy, 45*time.Minute, 80, nil)
+
+	// The check due at 10:40 and the expiry at 10:45 wait for 11:00
+	want := map[int]Kind{20: KindCheck, 60: KindExpired, 70: KindNag, 80: KindNag}
+	if len(sent) != len(want) {
+		t.Fatalf("sent = %+v, want %v", sent, want)
+	}
+	for m, k := range want {
+		if sent[m].Kind != k {
+			t.Errorf("minute %d: got %+v, want kind %d", m, sent[m], k)
+		}
+	}
+}
+
+func TestQuietHoursContains(t *testing.T) {
+	tests := []struct {
+		start, end string
+		at         string
+		want       bool
+	}{
+		{"22:00", "08:00", "23:30", true},
+		{"22:00", "08:00", "07:59", true},
+		{"22:00", "08:00", "08:00", false},
+		{"22:00", "08:00", "12:00", false},
+		{"12:00", "13:00", "12:00", true},
+		{"12:00", "13:00", "13:00", false},
+		{"09:00", "09:00", "09:00", false},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuietHours(tt.start, tt.end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		at, _ := time.Parse("15:04", tt.at)
+		if got := q.Contains(at); got != tt.want {
+			t.Errorf("%s-%s contains %s = %v, want %v", tt.start, tt.end, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyFrom(t *testing.T) {
+	policy, err := PolicyFrom(config.Reminders{
+		Interval: "15m",
+		Nags:     []string{"5m"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.Interval != 15*time.Minute || len(policy.Nags) != 1 || len(policy.Warnings) != 1 {
+		t.Errorf("policy = %+v", policy)
+	}
+
+	if _, err := PolicyFrom(config.Reminders{Warnings: []string{"soon"}}); err == nil {
+		t.Error("want an error for an invalid warning")
+	}
+	if _, err := ParseQuietHours("25:00", "08:00"); err == nil {
+		t.Error("want an error for hour 25")
+	}
+}
@@ -0,0 +1,307 @@
+package review
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/session"
+	"github.com/n3sty/focus/internal/worklog"
+)
+
+// maxOverruns is how many of the biggest overruns a report lists
+const maxOverruns = 5
+
+// Report sums up the sessions worked on between From and To
+type Report struct {
+	From time.Time
+	To   time.Time
+
+	Completed  []Summary // Merged sessions that ended in the period
+	Abandoned  []Summary // Discarded sessions that ended in the period
+	InProgress []Summary // Active or paused sessions worked on in the period
+
+	Planned time.Duration // Timeboxes of the ended sessions
+	Actual  time.Duration // Time spent on the ended sessions
+
+	Overruns    []Summary       // Biggest first
+	Categories  []CategoryCount // Most drifts first
+	FollowUps   []*session.Session
+	Reflections []Entry // Oldest first
+}
+
+// Summary is one session's planned and actual time
+type Summary struct {
+	Session *session.Session
+	Planned time.Duration // Zero if the timebox doesn't parse
+	Actual  time.Duration // Focused time over the whole session
+	InRange time.Duration // Focused time within the report period
+}
+
+// Overrun returns how far the session went past its timebox
+func (s Summary) Overrun() time.Duration {
+	if s.Planned == 0 || s.Actual <= s.Planned {
+		return 0
+	}
+	return s.Actual - s.Planned
+}
+
+// CategoryCount is how often a kind of drift came up
+type CategoryCount struct {
+	Category    session.Category
+	Drifts      int
+	Sessions    int // Sessions it came up in
+	MinutesLost int
+}
+
+// Recurring reports whether the drift came up in more than one session
+func (c CategoryCount) Recurring() bool {
+	return c.Sessions > 1
+}
+
+// Entry is a reflection together with the session it was written for
+type Entry struct {
+	Task       string
+	Reflection session.Reflection
+}
+
+// Build creates a report for the sessions between from and to. Parked
+// sessions that are still waiting are listed as follow-ups whenever they
+// were created.
+func Build(entries []worklog.Entry, from, to time.Time) Report {
+	r := Report{From: from, To: to}
+	categories := map[session.Category]*CategoryCount{}
+
+	for _, in := range entries {
+		sess := in.Session
+		if sess.ParkedFrom != "" && sess.Status == "paused" {
+			r.FollowUps = append(r.FollowUps, sess)
+		}
+
+		summary := Summary{
+			Session: sess,
+			Actual:  worklog.Total(in.Intervals),
+			InRange: worklog.Total(worklog.Clip(in.Intervals, from, to)),
+		}
+		if d, err := time.ParseDuration(sess.TimeBox); err == nil {
+			summary.Planned = d
+		}
+
+		ended := sess.EndTime != nil && !sess.EndTime.Before(from) && sess.EndTime.Before(to)
+		switch {
+		case ended && sess.Status == "completed":
+			r.Completed = append(r.Completed, summary)
+		case ended:
+			r.Abandoned = append(r.Abandoned, summary)
+		case sess.EndTime == nil && summary.InRange > 0:
+			r.InProgress = append(r.InProgress, summary)
+		default:
+			continue
+		}
+
+		if ended {
+			r.Planned += summary.Planned
+			r.Actual += summary.Actual
+			if summary.Overrun() > 0 {
+				r.Overruns = append(r.Overruns, summary)
+			}
+		}
+
+		seen := map[session.Category]bool{}
+		for _, d := range sess.Drifts {
+			if d.Timestamp.Before(from) || !d.Timestamp.Before(to) {
+				continue
+			}
+			count, ok := categories[d.Category]
+			if !ok {
+				count = &CategoryCount{Category: d.Category}
+				categories[d.Category] = count
+			}
+			count.Drifts++
+			count.MinutesLost += d.MinutesLost
+			if !seen[d.Category] {
+				seen[d.Category] = true
+				count.Sessions++
+			}
+		}
+
+		for _, reflection := range sess.Reflections {
+			if !reflection.Time.Before(from) && reflection.Time.Before(to) {
+				r.Reflections = append(r.Reflections, Entry{Task: sess.Task, Reflection: reflection})
+			}
+		}
+	}
+
+	sort.Slice(r.Overruns, func(i, j int) bool { return r.Overruns[i].Overrun() > r.Overruns[j].Overrun() })
+	if len(r.Overruns) > maxOverruns {
+		r.Overruns = r.Overruns[:maxOverruns]
+	}
+
+	for _, count := range categories {
+		r.Categories = append(r.Categories, *count)
+	}
+	sort.Slice(r.Categories, func(i, j int) bool {
+		a, b := r.Categories[i], r.Categories[j]
+		if a.Drifts != b.Drifts {
+			return a.Drifts > b.Drifts
+		}
+		if a.MinutesLost != b.MinutesLost {
+			return a.MinutesLost > b.MinutesLost
+		}
+		return a.Category < b.Category
+	})
+
+	sort.Slice(r.FollowUps, func(i, j int) bool { return r.FollowUps[i].StartTime.Before(r.FollowUps[j].StartTime) })
+	sort.Slice(r.Reflections, func(i, j int) bool { return r.Reflections[i].Reflection.Time.Before(r.Reflections[j].Reflection.Time) })
+
+	return r
+}
+
+// Load builds a report from every ended, paused and active session
+func Load(from, to, now time.Time) (Report, error) {
+	entries, err := worklog.LoadAll(now)
+	if err != nil {
+		return Report{}, err
+	}
+	return Build(entries, from, to), nil
+}
+
+// Ended returns how many sessions were merged or discarded
+func (r Report) Ended() int {
+	return len(r.Completed) + len(r.Abandoned)
+}
+
+// AverageRating returns the mean focus self-rating, or 0 if none were given
+func (r Report) AverageRating() float64 {
+	sum, count := 0, 0
+	for _, e := range r.Reflections {
+		if e.Reflection.Rating > 0 {
+			sum += e.Reflection.Rating
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return float64(sum) / float64(count)
+}
+
+// Markdown renders the report for sharing, e.g. in a team retro
+func (r Report) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Focus review: %s – %s\n\n", r.From.Format("Mon Jan 2"), r.To.Format("Mon Jan 2"))
+
+	b.WriteString("## Goals\n\n")
+	fmt.Fprintf(&b, "- Completed: %d\n", len(r.Completed))
+	fmt.Fprintf(&b, "- Abandoned: %d\n", len(r.Abandoned))
+	fmt.Fprintf(&b, "- In progress: %d\n", len(r.InProgress))
+	if r.Ended() > 0 {
+		fmt.Fprintf(&b, "- Planned %s, spent %s (%s)\n", formatDuration(r.Planned), formatDuration(r.Actual), r.Variance())
+	}
+	b.WriteString("\n")
+
+	for _, group := range []struct {
+		title     string
+		summaries []Summary
+	}{
+		{"Completed", r.Completed},
+		{"Abandoned", r.Abandoned},
+		{"In progress", r.InProgress},
+	} {
+		if len(group.summaries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "### %s\n\n", group.title)
+		for _, s := range group.summaries {
+			fmt.Fprintf(&b, "- %s (%s of %s)\n", s.Session.Task, formatDuration(s.Actual), s.Session.TimeBox)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.Overruns) > 0 {
+		b.WriteString("## Biggest overruns\n\n")
+		b.WriteString("| Goal | Planned | Actual | Over |\n|---|---|---|---|\n")
+		for _, s := range r.Overruns {
+			fmt.Fprintf(&b, "| %s | %s | %s | +%s |\n",
+				markdownCell(s.Session.Task), formatDuration(s.Planned), formatDuration(s.Actual), formatDuration(s.Overrun()))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.Categories) > 0 {
+		b.WriteString("## Drifts\n\n")
+		b.WriteString("| Category | Drifts | Sessions | Time lost |\n|---|---|---|---|\n")
+		for _, c := range r.Categories {
+			fmt.Fprintf(&b, "| %s | %d | %d | %s |\n",
+				c.Category.Label(), c.Drifts, c.Sessions, formatDuration(time.Duration(c.MinutesLost)*time.Minute))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.FollowUps) > 0 {
+		b.WriteString("## Parked follow-ups\n\n")
+		for _, sess := range r.FollowUps {
+			fmt.Fprintf(&b, "- %s (parked %s)\n", sess.Task, sess.StartTime.Format("Mon Jan 2"))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.Reflections) > 0 {
+		b.WriteString("## Reflections\n\n")
+		if avg := r.AverageRating(); avg > 0 {
+			fmt.Fprintf(&b, "Average focus: %.1f/5\n\n", avg)
+		}
+		for _, e := range r.Reflections {
+			fmt.Fprintf(&b, "### %s\n\n", e.Task)
+			if e.Reflection.Rating > 0 {
+				fmt.Fprintf(&b, "- **Focus:** %d/5\n", e.Reflection.Rating)
+			}
+			if e.Reflection.Done != "" {
+				fmt.Fprintf(&b, "- **Done:** %s\n", e.Reflection.Done)
+			}
+			if e.Reflection.Blocked != "" {
+				fmt.Fprintf(&b, "- **Blocked:** %s\n", e.Reflection.Blocked)
+			}
+			if e.Reflection.Learned != "" {
+				fmt.Fprintf(&b, "- **Learned:** %s\n", e.Reflection.Learned)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// Variance describes actual against planned time, e.g. "25% over plan"
+func (r Report) Variance() string {
+	if r.Planned == 0 {
+		return "no timebox"
+	}
+	percent := float64(r.Actual-r.Planned) / float64(r.Planned) * 100
+	switch {
+	case percent >= 0.5:
+		return fmt.Sprintf("%.0f%% over plan", percent)
+	case percent <= -0.5:
+		return fmt.Sprintf("%.0f%% under plan", -percent)
+	default:
+		return "on plan"
+	}
+}
+
+// formatDuration renders a duration as "1h 20m" or "45m"
+func formatDuration(d time.Duration) string {
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+
+	if h > 0 {
+		return fmt.Sprintf("%dh %dm", h, m)
+	}
+	return fmt.Sprintf("%dm", m)
+}
+
+// markdownCell escapes pipes so text can't break a table row
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,102 @@
+package session
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Clone returns a deep copy of the session, to compare against later with
+// Merge
+func (s *Session) Clone() *Session {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil
+	}
+
+	var clone Session
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil
+	}
+	return &clone
+}
+
+// Merge folds in changes another process (usually the watcher) saved
+// while s was being edited, so saving s doesn't overwrite them. base is
+// the session as s was loaded and theirs is what's on disk now.
+//
+// Fields s left alone take their value from theirs; fields s changed keep
+// its value. Logs both sides append to (drifts, check-ins, notes,
+// reflections, pauses) are combined instead.
+func (s *Session) Merge(base, theirs *Session) {
+	if base == nil || theirs == nil {
+		return
+	}
+
+	drifts := mergeLog(base.Drifts, s.Drifts, theirs.Drifts)
+	checkIns := mergeLog(base.CheckIns, s.CheckIns, theirs.CheckIns)
+	notes := mergeLog(base.Notes, s.Notes, theirs.Notes)
+	reflections := mergeLog(base.Reflections, s.Reflections, theirs.Reflections)
+	pauses := mergePauses(base.Pauses, s.Pauses, theirs.Pauses)
+
+	mine := reflect.ValueOf(s).Elem()
+	was := reflect.ValueOf(base).Elem()
+	now := reflect.ValueOf(theirs).Elem()
+	for i := 0; i < mine.NumField(); i++ {
+		if reflect.DeepEqual(mine.Field(i).Interface(), was.Field(i).Interface()) {
+			mine.Field(i).Set(now.Field(i))
+		}
+	}
+
+	s.Drifts = drifts
+	s.CheckIns = checkIns
+	s.Notes = notes
+	s.Reflections = reflections
+	s.Pauses = pauses
+}
+
+// mergeLog appends the entries mine added since base to theirs
+func mergeLog[T any](base, mine, theirs []T) []T {
+	if len(mine) <= len(base) {
+		return theirs
+	}
+	merged := append([]T(nil), theirs...)
+	return append(merged, mine[len(base):]...)
+}
+
+// mergePauses starts from theirs and applies the reviews mine made to
+// pauses that existed in base: marking one as a break, or dropping it
+// because it was part of the work
+func mergePauses(base, mine, theirs []Pause) []Pause {
+	find := func(pauses []Pause, p Pause) int {
+		for i, q := range pauses {
+			if q.Start.Equal(p.Start) && q.Reason == p.Reason {
+				return i
+			}
+		}
+		return -1
+	}
+
+	merged := append([]Pause(nil), theirs...)
+	for _, p := range base {
+		i := find(merged, p)
+		if i < 0 {
+			continue
+		}
+		j := find(mine, p)
+		switch {
+		case j < 0:
+			merged = append(merged[:i], merged[i+1:]...)
+		case mine[j].Reviewed && !p.Reviewed:
+			merged[i].Reviewed = true
+		}
+	}
+
+	// Pauses mine opened itself
+	for _, p := range mine {
+		if find(base, p) < 0 && find(merged, p) < 0 {
+			merged = append(merged, p)
+		}
+	}
+
+	return merged
+}
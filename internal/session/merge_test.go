@@ -0,0 +1,78 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/n3sty/focus/internal/pomodoro"
+)
+
+func TestMergeKeepsBothSides(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	idleEnd := start.Add(40 * time.Minute)
+
+	base := &Session{
+		ID:        "s",
+		Task:      "Write the parser",
+		StartTime: start,
+		TimeBox:   "2h",
+		Status:    "active",
+		Pauses:    []Pause{{Start: start.Add(30 * time.Minute), End: &idleEnd, Reason: "idle"}},
+		Pomodoro:  pomodoro.New(pomodoro.DefaultPlan, start),
+	}
+
+	// 'focus check': reviews the idle pause, logs a drift and checks in
+	mine := base.Clone()
+	mine.ReviewIdle(true)
+	mine.AddDrift("Slack", "", start.Add(time.Hour))
+	mine.AddCheckIn("no", "", "check", start.Add(time.Hour))
+
+	// Watcher, meanwhile: prompts, starts a break and logs branch drift
+	theirs := base.Clone()
+	theirs.Prompt(start.Add(50 * time.Minute))
+	theirs.Pomodoro.Phase = pomodoro.PhaseShortBreak
+	theirs.StartBreak(start.Add(55 * time.Minute))
+	theirs.LogDrift(Drift{Description: "Worked on branch 'main'", Automatic: true})
+
+	mine.Merge(base, theirs)
+
+	if mine.PromptsSent != 1 || mine.LastPrompt == nil {
+		t.Errorf("prompt lost: sent=%d last=%v", mine.PromptsSent, mine.LastPrompt)
+	}
+	if mine.Pomodoro.Phase != pomodoro.PhaseShortBreak {
+		t.Errorf("pomodoro phase = %s, want short break", mine.Pomodoro.Phase)
+	}
+	if len(mine.Drifts) != 2 || !mine.Drifts[0].Automatic || mine.Drifts[1].Description != "Slack" {
+		t.Errorf("drifts = %+v", mine.Drifts)
+	}
+	if len(mine.CheckIns) != 1 {
+		t.Errorf("check-ins = %+v", mine.CheckIns)
+	}
+	if len(mine.Pauses) != 2 || !mine.Pauses[0].Reviewed || mine.Pauses[1].Reason != "break" {
+		t.Errorf("pauses = %+v", mine.Pauses)
+	}
+}
+
+func TestMergeDropsPauseReviewedAsWork(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	idleEnd := start.Add(40 * time.Minute)
+
+	base := &Session{ID: "s", StartTime: start, Status: "active",
+		Pauses: []Pause{{Start: start.Add(30 * time.Minute), End: &idleEnd, Reason: "idle"}}}
+
+	mine := base.Clone()
+	mine.ReviewIdle(false)
+	mine.Status = "paused"
+
+	theirs := base.Clone()
+	theirs.StartIdle(start.Add(time.Hour))
+
+	mine.Merge(base, theirs)
+
+	if len(mine.Pauses) != 1 || !mine.Pauses[0].Start.Equal(start.Add(time.Hour)) {
+		t.Errorf("pauses = %+v", mine.Pauses)
+	}
+	if mine.Status != "paused" {
+		t.Errorf("status = %s, want paused", mine.Status)
+	}
+}
@@ -4,33 +4,307 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/issue"
+	"github.com/n3sty/focus/internal/pomodoro"
 )
 
 // Session represents a focus session
 type Session struct {
-	ID        string    `json:"id"`
-	Task      string    `json:"task"`
-	StartTime time.Time `json:"start_time"`
-	TimeBox   string    `json:"timebox"`
-	Branch    string    `json:"branch"`
-	Drifts    []Drift   `json:"drifts"`
-	Status    string    `json:"status"` // "active" or "paused"
+	ID        string       `json:"id"`
+	Task      string       `json:"task"`
+	StartTime time.Time    `json:"start_time"`
+	TimeBox   string       `json:"timebox"`
+	Branch    string       `json:"branch"`
+	Issue     *issue.Issue `json:"issue,omitempty"` // Tracker issue the session works on
+	Drifts    []Drift      `json:"drifts"`
+	Status    string       `json:"status"` // "active", "paused", or "completed"/"abandoned" once archived
+	EndTime   *time.Time   `json:"end_time,omitempty"`
+
+	OffBranch *OffBranch `json:"off_branch,omitempty"`
+	Pauses    []Pause    `json:"pauses,omitempty"`
+
+	Pomodoro     *pomodoro.State `json:"pomodoro,omitempty"`
+	SnoozedUntil *time.Time      `json:"snoozed_until,omitempty"`
+
+	Steps      []Step `json:"steps,omitempty"`
+	ActiveStep int    `json:"active_step,omitempty"` // Index of the step the user said they're on
+
+	Criteria []Criterion `json:"criteria,omitempty"` // Definition of done, checked by 'focus end'
+
+	Notes       []Note       `json:"notes,omitempty"`
+	Links       []string     `json:"links,omitempty"`
+	Reflections []Reflection `json:"reflections,omitempty"` // One per 'focus end', oldest first
+
+	ParkedFrom string `json:"parked_from,omitempty"` // Session this one was split off from
+	Stash      string `json:"stash,omitempty"`       // Stashed changes to restore on resume
+
+	CheckIns    []CheckIn  `json:"check_ins,omitempty"`
+	LastPrompt  *time.Time `json:"last_prompt,omitempty"` // Last time the watcher asked for a check-in
+	PromptsSent int        `json:"prompts_sent,omitempty"`
+}
+
+// Step is one item on a session's checklist
+type Step struct {
+	Title  string     `json:"title"`
+	DoneAt *time.Time `json:"done_at,omitempty"`
+}
+
+// Done reports whether the step is checked off
+func (s Step) Done() bool {
+	return s.DoneAt != nil
+}
+
+// Criterion is one acceptance criterion. With a Command it's verified by
+// running it; without one the user confirms it at the end.
+type Criterion struct {
+	Description string           `json:"description"`
+	Command     string           `json:"command,omitempty"`
+	Confirmed   bool             `json:"confirmed,omitempty"` // Manual criteria only
+	Result      *CriterionResult `json:"result,omitempty"`    // Last run of Command
+}
+
+// CriterionResult is the outcome of running a criterion's command
+type CriterionResult struct {
+	Passed   bool          `json:"passed"`
+	Output   string        `json:"output,omitempty"` // Tail of stdout and stderr
+	RanAt    time.Time     `json:"ran_at"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Met reports whether the criterion is satisfied
+func (c Criterion) Met() bool {
+	if c.Command == "" {
+		return c.Confirmed
+	}
+	return c.Result != nil && c.Result.Passed
+}
+
+// CriteriaMet returns how many acceptance criteria are satisfied
+func (s *Session) CriteriaMet() (met, total int) {
+	for _, c := range s.Criteria {
+		if c.Met() {
+			met++
+		}
+	}
+	return met, len(s.Criteria)
+}
+
+// Note is a remark attached to a session
+type Note struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// AddNote attaches a note to the session
+func (s *Session) AddNote(text string, at time.Time) {
+	s.Notes = append(s.Notes, Note{Time: at, Text: text})
+}
+
+// Reflection is what the user wrote down when ending or pausing the
+// session
+type Reflection struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"` // "merge", "pause" or "discard"
+	Done    string    `json:"done,omitempty"`
+	Blocked string    `json:"blocked,omitempty"`
+	Learned string    `json:"learned,omitempty"`
+	Rating  int       `json:"rating,omitempty"` // Focus self-rating 1-5, 0 if skipped
+}
+
+// Empty reports whether every question was skipped
+func (r Reflection) Empty() bool {
+	return r.Done == "" && r.Blocked == "" && r.Learned == "" && r.Rating == 0
+}
+
+// LastReflection returns the most recent reflection, or nil
+func (s *Session) LastReflection() *Reflection {
+	if len(s.Reflections) == 0 {
+		return nil
+	}
+	return &s.Reflections[len(s.Reflections)-1]
+}
+
+// CheckIn is one answer to "are you still on track?"
+type CheckIn struct {
+	Time    time.Time     `json:"time"`
+	Answer  string        `json:"answer"` // "yes", "no", "defer" or "switch"
+	Note    string        `json:"note,omitempty"`
+	Source  string        `json:"source"`            // "check" or "notification"
+	Step    string        `json:"step,omitempty"`    // Step the user said they're on
+	Latency time.Duration `json:"latency,omitempty"` // Time since the prompt it answers; 0 if unprompted
+}
+
+// Prompted reports whether the check-in answered a reminder
+func (c CheckIn) Prompted() bool {
+	return c.Latency > 0
+}
+
+// Pause is a period where focused time stopped counting
+type Pause struct {
+	Start    time.Time  `json:"start"`
+	End      *time.Time `json:"end,omitempty"`      // nil while still paused
+	Reason   string     `json:"reason"`             // "idle", "break", "meeting" or "paused"
+	Reviewed bool       `json:"reviewed,omitempty"` // User confirmed it was a break
 }
 
+// Duration returns how long the pause lasted (up to now if still open)
+func (p Pause) Duration(now time.Time) time.Duration {
+	end := now
+	if p.End != nil {
+		end = *p.End
+	}
+	if end.Before(p.Start) {
+		return 0
+	}
+	return end.Sub(p.Start)
+}
+
+// OffBranch records that the user left the focus branch mid-session
+type OffBranch struct {
+	Branch string    `json:"branch"`
+	Since  time.Time `json:"since"`
+}
+
+// BranchChange describes the outcome of comparing HEAD with the focus branch
+type BranchChange int
+
+const (
+	BranchUnchanged BranchChange = iota
+	BranchLeft                   // User just switched away from the focus branch
+	BranchReturned               // User came back to the focus branch
+)
+
 // Drift represents a moment when the user went off-track
 type Drift struct {
 	Timestamp   time.Time `json:"timestamp"`
 	Description string    `json:"description"`
 	Reason      string    `json:"reason,omitempty"`
+	Automatic   bool      `json:"automatic,omitempty"` // Logged by focus, not the user
+
+	Category    Category `json:"category,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	MinutesLost int      `json:"minutes_lost,omitempty"` // User's estimate
+	Severity    Severity `json:"severity,omitempty"`
+}
+
+// Details summarizes the drift's category, cost, severity and tags
+func (d Drift) Details() string {
+	var parts []string
+	if d.Category != "" {
+		parts = append(parts, d.Category.Label())
+	}
+	if d.MinutesLost > 0 {
+		parts = append(parts, fmt.Sprintf("%dm lost", d.MinutesLost))
+	}
+	if d.Severity != "" {
+		parts = append(parts, string(d.Severity)+" severity")
+	}
+	for _, tag := range d.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Fields describes the drift as string pairs for the event log
+func (d Drift) Fields() map[string]string {
+	data := map[string]string{"description": d.Description, "reason": d.Reason}
+	if d.Automatic {
+		data["automatic"] = "true"
+	}
+	if d.Category != "" {
+		data["category"] = string(d.Category)
+	}
+	if len(d.Tags) > 0 {
+		data["tags"] = strings.Join(d.Tags, ",")
+	}
+	if d.MinutesLost > 0 {
+		data["minutes_lost"] = strconv.Itoa(d.MinutesLost)
+	}
+	if d.Severity != "" {
+		data["severity"] = string(d.Severity)
+	}
+	return data
+}
+
+// Category is the kind of rabbit hole a drift went down. The predefined
+// ones are offered in the check TUI; anything else the user types is kept
+// as-is.
+type Category string
+
+const (
+	CategoryBug       Category = "bug"
+	CategoryYakShave  Category = "yak-shave"
+	CategoryMeeting   Category = "meeting"
+	CategoryRefactor  Category = "refactor"
+	CategoryResearch  Category = "research"
+	CategoryOffBranch Category = "off-branch" // Automatic drifts from leaving the focus branch
+)
+
+// Categories are the choices offered when logging a drift
+var Categories = []Category{
+	CategoryBug,
+	CategoryYakShave,
+	CategoryMeeting,
+	CategoryRefactor,
+	CategoryResearch,
+}
+
+var categoryLabels = map[Category]string{
+	CategoryBug:       "Bug found",
+	CategoryYakShave:  "Yak shave",
+	CategoryMeeting:   "Meeting",
+	CategoryRefactor:  "Refactor urge",
+	CategoryResearch:  "Research",
+	CategoryOffBranch: "Off branch",
+}
+
+// Label is a human-readable name for the category
+func (c Category) Label() string {
+	if label, ok := categoryLabels[c]; ok {
+		return label
+	}
+	if c == "" {
+		return "Uncategorized"
+	}
+	return string(c)
+}
+
+// Severity is how much a drift hurt the session's goal
+type Severity string
+
+const (
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+// ParseTags splits "ci, flaky tests" style input into trimmed, lowercase
+// tags, dropping duplicates and empties
+func ParseTags(input string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, tag := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == '#' }) {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
 }
 
 const focusDir = ".focus"
 const sessionsDir = ".focus/sessions"
+const archiveDir = ".focus/archive"
 const activeFile = ".focus/active"
 
 // Load reads the currently active session
@@ -60,6 +334,15 @@ func LoadByID(id string) (*Session, error) {
 	return &session, nil
 }
 
+// ModTime returns when a session was last written to disk
+func ModTime(id string) (time.Time, error) {
+	info, err := os.Stat(filepath.Join(sessionsDir, id+".json"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
 // Save writes the session to disk
 func (s *Session) Save() error {
 	if err := os.MkdirAll(sessionsDir, 0755); err != nil {
@@ -84,18 +367,341 @@ func (s *Session) Save() error {
 	return nil
 }
 
-// AddDrift logs a distraction
-func (s *Session) AddDrift(description, reason string) {
-	drift := Drift{
-		Timestamp:   time.Now(),
-		Description: description,
-		Reason:      reason,
+// AddDrift logs a distraction noticed at the given time
+func (s *Session) AddDrift(description, reason string, at time.Time) {
+	s.LogDrift(Drift{Description: description, Reason: reason, Timestamp: at})
+}
+
+// LogDrift logs a categorized distraction, stamped now unless it already
+// has a time
+func (s *Session) LogDrift(drift Drift) {
+	if drift.Timestamp.IsZero() {
+		drift.Timestamp = time.Now()
 	}
 	s.Drifts = append(s.Drifts, drift)
 }
 
-// Pause marks the session as paused
+// LastDrift returns the most recently logged drift, if any
+func (s *Session) LastDrift() *Drift {
+	if len(s.Drifts) == 0 {
+		return nil
+	}
+	return &s.Drifts[len(s.Drifts)-1]
+}
+
+// AddStep appends a step to the checklist
+func (s *Session) AddStep(title string) {
+	s.Steps = append(s.Steps, Step{Title: title})
+}
+
+// CompleteStep checks off step i (0-based) and moves on to the next open
+// step
+func (s *Session) CompleteStep(i int, at time.Time) error {
+	if i < 0 || i >= len(s.Steps) {
+		return fmt.Errorf("no step %d (have %d)", i+1, len(s.Steps))
+	}
+	if s.Steps[i].Done() {
+		return fmt.Errorf("step %d is already done", i+1)
+	}
+	s.Steps[i].DoneAt = &at
+
+	if next := s.NextStep(); next >= 0 {
+		s.ActiveStep = next
+	}
+	return nil
+}
+
+// NextStep returns the index of the first open step, or -1 when all are
+// done
+func (s *Session) NextStep() int {
+	for i, step := range s.Steps {
+		if !step.Done() {
+			return i
+		}
+	}
+	return -1
+}
+
+// CurrentStep returns the index of the step being worked on, or -1 when
+// there are no open steps
+func (s *Session) CurrentStep() int {
+	if s.ActiveStep >= 0 && s.ActiveStep < len(s.Steps) && !s.Steps[s.ActiveStep].Done() {
+		return s.ActiveStep
+	}
+	return s.NextStep()
+}
+
+// StepProgress returns how many steps are done out of the total
+func (s *Session) StepProgress() (done, total int) {
+	for _, step := range s.Steps {
+		if step.Done() {
+			done++
+		}
+	}
+	return done, len(s.Steps)
+}
+
+// Prompt records that the watcher asked for a check-in
+func (s *Session) Prompt(at time.Time) {
+	s.LastPrompt = &at
+	s.PromptsSent++
+}
+
+// AddCheckIn stores an answer to a check-in. The first answer after a
+// prompt records how long the prompt went unanswered.
+func (s *Session) AddCheckIn(answer, note, source string, at time.Time) {
+	checkIn := CheckIn{Time: at, Answer: answer, Note: note, Source: source}
+
+	if s.LastPrompt != nil && !at.Before(*s.LastPrompt) {
+		last := s.LastCheckIn()
+		if last == nil || last.Time.Before(*s.LastPrompt) {
+			checkIn.Latency = max(at.Sub(*s.LastPrompt), time.Nanosecond)
+		}
+	}
+
+	s.CheckIns = append(s.CheckIns, checkIn)
+}
+
+// LastCheckIn returns the most recent check-in, if any
+func (s *Session) LastCheckIn() *CheckIn {
+	if len(s.CheckIns) == 0 {
+		return nil
+	}
+	return &s.CheckIns[len(s.CheckIns)-1]
+}
+
+// CheckInSummary counts a session's check-ins by answer
+type CheckInSummary struct {
+	Total      int
+	OnTrack    int // "yes" and "switch"
+	Drifted    int
+	Deferred   int
+	Prompts    int           // Check-in prompts the watcher sent
+	Prompted   int           // Check-ins that answered a prompt
+	AvgLatency time.Duration // Mean time to answer a prompt
+}
+
+// Compliance is the share of prompts that got an answer, 0 without prompts
+func (c CheckInSummary) Compliance() float64 {
+	if c.Prompts == 0 {
+		return 0
+	}
+	return min(float64(c.Prompted)/float64(c.Prompts), 1)
+}
+
+// CheckInSummary totals the session's check-ins
+func (s *Session) CheckInSummary() CheckInSummary {
+	sum := CheckInSummary{Prompts: s.PromptsSent}
+	var latency time.Duration
+
+	for _, c := range s.CheckIns {
+		sum.Total++
+		switch c.Answer {
+		case "yes", "switch":
+			sum.OnTrack++
+		case "no":
+			sum.Drifted++
+		case "defer":
+			sum.Deferred++
+		}
+		if c.Prompted() {
+			sum.Prompted++
+			latency += c.Latency
+		}
+	}
+
+	if sum.Prompted > 0 {
+		sum.AvgLatency = latency / time.Duration(sum.Prompted)
+	}
+	return sum
+}
+
+// TrackBranch compares the current git branch with the focus branch.
+// Leaving the branch starts an off-branch period; returning closes it and
+// logs the time spent elsewhere as an automatic drift.
+func (s *Session) TrackBranch(current string, now time.Time) BranchChange {
+	// Detached HEAD or unknown branch - nothing to compare
+	if current == "" {
+		return BranchUnchanged
+	}
+
+	if current != s.Branch {
+		if s.OffBranch != nil {
+			return BranchUnchanged
+		}
+		s.OffBranch = &OffBranch{Branch: current, Since: now}
+		return BranchLeft
+	}
+
+	if s.OffBranch == nil {
+		return BranchUnchanged
+	}
+
+	off := s.OffBranch
+	s.OffBranch = nil
+	s.Drifts = append(s.Drifts, Drift{
+		Timestamp:   off.Since,
+		Description: fmt.Sprintf("Worked on branch '%s' for %s", off.Branch, now.Sub(off.Since).Round(time.Minute)),
+		Reason:      "Left the focus branch",
+		Automatic:   true,
+		Category:    CategoryOffBranch,
+		MinutesLost: int(now.Sub(off.Since).Round(time.Minute).Minutes()),
+	})
+	return BranchReturned
+}
+
+// Extend adds d to the session's timebox
+func (s *Session) Extend(d time.Duration) error {
+	current, err := time.ParseDuration(s.TimeBox)
+	if err != nil {
+		return fmt.Errorf("invalid timebox %q: %w", s.TimeBox, err)
+	}
+	s.TimeBox = FormatTimebox(current + d)
+	return nil
+}
+
+// FormatTimebox renders a duration the way users type timeboxes ("3h15m")
+func FormatTimebox(d time.Duration) string {
+	str := d.Round(time.Minute).String()
+	str = strings.TrimSuffix(str, "0s")
+	if strings.HasSuffix(str, "h0m") {
+		str = strings.TrimSuffix(str, "0m")
+	}
+	return str
+}
+
+// Snooze silences watcher reminders for d from now (0 clears an existing
+// snooze)
+func (s *Session) Snooze(d time.Duration, now time.Time) {
+	if d <= 0 {
+		s.SnoozedUntil = nil
+		return
+	}
+	until := now.Add(d)
+	s.SnoozedUntil = &until
+}
+
+// OpenPause returns the pause currently in progress, if any
+func (s *Session) OpenPause() *Pause {
+	for i := range s.Pauses {
+		if s.Pauses[i].End == nil {
+			return &s.Pauses[i]
+		}
+	}
+	return nil
+}
+
+// StartIdle stops focused time from the moment activity was last seen
+func (s *Session) StartIdle(since time.Time) {
+	s.startPause("idle", since)
+}
+
+// EndIdle resumes focused time after an idle pause
+func (s *Session) EndIdle(at time.Time) {
+	s.endPause("idle", at)
+}
+
+// StartBreak stops focused time for a pomodoro break
+func (s *Session) StartBreak(at time.Time) {
+	s.startPause("break", at)
+}
+
+// EndBreak resumes focused time after a pomodoro break
+func (s *Session) EndBreak(at time.Time) {
+	s.endPause("break", at)
+}
+
+// StartMeeting stops focused time while a calendar meeting runs
+func (s *Session) StartMeeting(at time.Time) {
+	s.startPause("meeting", at)
+}
+
+// EndMeeting resumes focused time after a meeting
+func (s *Session) EndMeeting(at time.Time) {
+	s.endPause("meeting", at)
+}
+
+func (s *Session) startPause(reason string, at time.Time) {
+	if s.OpenPause() != nil {
+		return
+	}
+	s.Pauses = append(s.Pauses, Pause{Start: at, Reason: reason})
+}
+
+func (s *Session) endPause(reason string, at time.Time) {
+	if p := s.OpenPause(); p != nil && p.Reason == reason {
+		p.End = &at
+	}
+}
+
+// UnreviewedIdle returns the first finished idle pause the user hasn't
+// classified yet
+func (s *Session) UnreviewedIdle() *Pause {
+	for i := range s.Pauses {
+		p := &s.Pauses[i]
+		if p.Reason == "idle" && p.End != nil && !p.Reviewed {
+			return p
+		}
+	}
+	return nil
+}
+
+// ReviewIdle records whether the oldest unreviewed idle pause was a break.
+// If it was part of the work, the pause is dropped so the time counts.
+func (s *Session) ReviewIdle(wasBreak bool) {
+	for i := range s.Pauses {
+		p := &s.Pauses[i]
+		if p.Reason != "idle" || p.End == nil || p.Reviewed {
+			continue
+		}
+		if wasBreak {
+			p.Reviewed = true
+		} else {
+			s.Pauses = append(s.Pauses[:i], s.Pauses[i+1:]...)
+		}
+		return
+	}
+}
+
+// FocusedTime returns time since start minus any pauses. Time covered
+// by more than one pause is only taken off once.
+func (s *Session) FocusedTime(now time.Time) time.Duration {
+	pauses := slices.Clone(s.Pauses)
+	sort.Slice(pauses, func(i, j int) bool { return pauses[i].Start.Before(pauses[j].Start) })
+
+	focused := now.Sub(s.StartTime)
+	cursor := s.StartTime
+	for _, p := range pauses {
+		start, end := p.Start, now
+		if p.End != nil && p.End.Before(now) {
+			end = *p.End
+		}
+		if start.Before(cursor) {
+			start = cursor
+		}
+		if end.After(start) {
+			focused -= end.Sub(start)
+			cursor = end
+		}
+	}
+	if focused < 0 {
+		return 0
+	}
+	return focused
+}
+
+// Pause marks the session as paused. Focused time stops until Activate.
 func (s *Session) Pause() error {
+	now := time.Now()
+	// Close any off-branch period so paused time isn't counted as drift
+	if s.OffBranch != nil {
+		s.TrackBranch(s.Branch, now)
+	}
+	// An idle stretch, break or meeting in progress ends here
+	if p := s.OpenPause(); p != nil && p.Reason != "paused" {
+		p.End = &now
+	}
+	s.startPause("paused", now)
 	s.Status = "paused"
 	return s.Save()
 }
@@ -108,6 +714,7 @@ func (s *Session) Activate() error {
 	}
 
 	s.Status = "active"
+	s.endPause("paused", time.Now())
 
 	// Make sure we're on the right git branch
 	currentBranch, err := git.GetCurrentBranch()
@@ -116,15 +723,96 @@ func (s *Session) Activate() error {
 	}
 
 	if currentBranch != s.Branch {
-		cmd := exec.Command("git", "switch", s.Branch)
-		if err := cmd.Run(); err != nil {
+		if err := git.SwitchBranch(s.Branch); err != nil {
 			return fmt.Errorf("failed to switch branch: %w", err)
 		}
 	}
 
+	// Bring back the changes that were parked with this session
+	if s.Stash != "" {
+		if err := git.PopStash(s.Stash); err != nil {
+			// The session is active on its branch either way
+			s.Save()
+			return fmt.Errorf("failed to restore parked changes (still in 'git stash list'): %w", err)
+		}
+		s.Stash = ""
+	}
+
 	return s.Save()
 }
 
+// Archive ends the session with an outcome ("completed" or "abandoned"),
+// moving it out of the session list into .focus/archive/
+func (s *Session) Archive(outcome string) error {
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	s.Status = outcome
+	s.EndTime = &now
+	// Log time still spent on another branch before the session closes
+	if s.OffBranch != nil {
+		s.TrackBranch(s.Branch, now)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(archiveDir, s.ID+".json"), data, 0644); err != nil {
+		return err
+	}
+
+	if err := s.Delete(); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// LoadArchived loads an ended session by ID
+func LoadArchived(id string) (*Session, error) {
+	data, err := os.ReadFile(filepath.Join(archiveDir, id+".json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// ListArchived returns every ended session, oldest first
+func ListArchived() ([]*Session, error) {
+	entries, err := os.ReadDir(archiveDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Session{}, nil
+		}
+		return nil, err
+	}
+
+	var archived []*Session
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		sess, err := LoadArchived(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		archived = append(archived, sess)
+	}
+
+	sort.Slice(archived, func(i, j int) bool { return archived[i].StartTime.Before(archived[j].StartTime) })
+	return archived, nil
+}
+
 // Delete removes the session from disk
 func (s *Session) Delete() error {
 	path := filepath.Join(sessionsDir, s.ID+".json")
@@ -0,0 +1,100 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArchiveLogsOffBranchTime(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	s := &Session{
+		ID:        "s",
+		Task:      "Write the parser",
+		Branch:    "focus/parser",
+		StartTime: time.Now().Add(-time.Hour),
+		Status:    "active",
+		OffBranch: &OffBranch{Branch: "main", Since: time.Now().Add(-20 * time.Minute)},
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Archive("completed"); err != nil {
+		t.Fatal(err)
+	}
+
+	archived, err := LoadArchived("s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archived.OffBranch != nil {
+		t.Errorf("off-branch period still open: %+v", archived.OffBranch)
+	}
+	if len(archived.Drifts) != 1 {
+		t.Fatalf("drifts = %+v, want the time on main", archived.Drifts)
+	}
+	d := archived.Drifts[0]
+	if d.Category != CategoryOffBranch || !d.Automatic || d.MinutesLost != 20 {
+		t.Errorf("drift = %+v, want 20 automatic minutes off-branch", d)
+	}
+}
+
+func TestPauseStopsFocusedTime(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	s := &Session{ID: "s", StartTime: time.Now().Add(-time.Hour), Status: "active"}
+	s.StartIdle(time.Now().Add(-10 * time.Minute))
+	if err := s.Pause(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.Pauses) != 2 || s.Pauses[0].End == nil || s.Pauses[1].Reason != "paused" || s.Pauses[1].End != nil {
+		t.Fatalf("pauses = %+v, want the idle stretch closed and an open pause", s.Pauses)
+	}
+	later := time.Now().Add(2 * time.Hour)
+	if got := s.FocusedTime(later).Round(time.Minute); got != 50*time.Minute {
+		t.Errorf("focused %s two hours after pausing, want the 50m before it", got)
+	}
+
+	// Pausing again keeps the first pause
+	if err := s.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Pauses) != 2 {
+		t.Errorf("pauses = %+v after pausing twice", s.Pauses)
+	}
+}
+
+func TestFocusedTime(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		ts := start.Add(time.Duration(minutes) * time.Minute)
+		return &ts
+	}
+
+	tests := []struct {
+		name   string
+		pauses []Pause
+		want   time.Duration
+	}{
+		{"no pauses", nil, time.Hour},
+		{"one pause", []Pause{{Start: *at(10), End: at(20)}}, 50 * time.Minute},
+		{"open pause", []Pause{{Start: *at(40)}}, 40 * time.Minute},
+		{
+			"overlapping pauses count once",
+			[]Pause{{Start: *at(25), End: at(30), Reason: "break"}, {Start: *at(20), End: at(40), Reason: "idle"}},
+			40 * time.Minute,
+		},
+		{"pause before start", []Pause{{Start: start.Add(-time.Hour), End: at(10)}}, 50 * time.Minute},
+		{"pause ending after now", []Pause{{Start: *at(50), End: at(90)}}, 50 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Session{StartTime: start, Pauses: tt.pauses}
+			if got := s.FocusedTime(*at(60)); got != tt.want {
+				t.Errorf("FocusedTime = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
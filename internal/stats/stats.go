@@ -0,0 +1,133 @@
+package stats
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/session"
+)
+
+// Report sums up drifts and check-ins across session event logs
+type Report struct {
+	Sessions    int
+	Drifts      int
+	MinutesLost int
+	Categories  []CategoryCost // Costliest first
+	Tags        []TagCount     // Most used first
+	Severities  map[session.Severity]int
+
+	CheckIns map[string]int // By answer: yes, no, defer, switch
+}
+
+// CategoryCost is what one kind of drift cost
+type CategoryCost struct {
+	Category    session.Category
+	Drifts      int
+	MinutesLost int
+	High        int // Drifts marked high severity
+}
+
+// TagCount is how often a tag was used
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// Build aggregates the given event logs, one per session
+func Build(logs [][]events.Event) Report {
+	r := Report{
+		Severities: map[session.Severity]int{},
+		CheckIns:   map[string]int{},
+	}
+	categories := map[session.Category]*CategoryCost{}
+	tags := map[string]int{}
+
+	for _, log := range logs {
+		if len(log) == 0 {
+			continue
+		}
+		r.Sessions++
+
+		for _, e := range log {
+			switch e.Type {
+			case events.CheckAnswered:
+				r.CheckIns[e.Data["answer"]]++
+
+			case events.DriftLogged:
+				category := session.Category(e.Data["category"])
+				cost, ok := categories[category]
+				if !ok {
+					cost = &CategoryCost{Category: category}
+					categories[category] = cost
+				}
+
+				minutes, _ := strconv.Atoi(e.Data["minutes_lost"])
+				severity := session.Severity(e.Data["severity"])
+
+				r.Drifts++
+				r.MinutesLost += minutes
+				cost.Drifts++
+				cost.MinutesLost += minutes
+				if severity != "" {
+					r.Severities[severity]++
+				}
+				if severity == session.SeverityHigh {
+					cost.High++
+				}
+
+				for _, tag := range strings.Split(e.Data["tags"], ",") {
+					if tag != "" {
+						tags[tag]++
+					}
+				}
+			}
+		}
+	}
+
+	for _, cost := range categories {
+		r.Categories = append(r.Categories, *cost)
+	}
+	sort.Slice(r.Categories, func(i, j int) bool {
+		a, b := r.Categories[i], r.Categories[j]
+		if a.MinutesLost != b.MinutesLost {
+			return a.MinutesLost > b.MinutesLost
+		}
+		if a.Drifts != b.Drifts {
+			return a.Drifts > b.Drifts
+		}
+		return a.Category < b.Category
+	})
+
+	for tag, count := range tags {
+		r.Tags = append(r.Tags, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(r.Tags, func(i, j int) bool {
+		if r.Tags[i].Count != r.Tags[j].Count {
+			return r.Tags[i].Count > r.Tags[j].Count
+		}
+		return r.Tags[i].Tag < r.Tags[j].Tag
+	})
+
+	return r
+}
+
+// Load builds a report from every session's event log
+func Load() (Report, error) {
+	ids, err := events.Sessions()
+	if err != nil {
+		return Report{}, err
+	}
+
+	logs := make([][]events.Event, 0, len(ids))
+	for _, id := range ids {
+		log, err := events.Load(id)
+		if err != nil {
+			return Report{}, err
+		}
+		logs = append(logs, log)
+	}
+
+	return Build(logs), nil
+}
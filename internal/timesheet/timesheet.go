@@ -0,0 +1,261 @@
+package timesheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/n3sty/focus/internal/config"
+	"github.com/n3sty/focus/internal/worklog"
+)
+
+// minEntry drops slivers of time too short to log
+const minEntry = time.Minute
+
+// Entry is one block of time on a timesheet
+type Entry struct {
+	ID          string    `json:"id"` // Stable per interval so re-pushing can update
+	SessionID   string    `json:"session_id"`
+	Project     string    `json:"project"`
+	Client      string    `json:"client,omitempty"`
+	Description string    `json:"description"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Tags        []string  `json:"tags,omitempty"`
+	Billable    bool      `json:"billable"`
+	Email       string    `json:"email,omitempty"`
+}
+
+// Duration returns the length of the entry
+func (e Entry) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
+
+// Options are the details a timesheet entry needs beyond the session
+type Options struct {
+	Project  string
+	Client   string
+	Billable bool
+	Email    string
+}
+
+// Build turns focused intervals into entries, split at midnight so each
+// entry falls on one day, oldest first
+func Build(entries []worklog.Entry, opts Options) []Entry {
+	var sheet []Entry
+	for _, e := range entries {
+		sess := e.Session
+		tags := driftTags(e)
+
+		for _, interval := range e.Intervals {
+			for _, part := range splitDays(interval) {
+				if part.Duration() < minEntry {
+					continue
+				}
+				sheet = append(sheet, Entry{
+					ID:          fmt.Sprintf("%s-%d", sess.ID, part.Start.Unix()),
+					SessionID:   sess.ID,
+					Project:     opts.Project,
+					Client:      opts.Client,
+					Description: sess.Task,
+					Start:       part.Start,
+					End:         part.End,
+					Tags:        tags,
+					Billable:    opts.Billable,
+					Email:       opts.Email,
+				})
+			}
+		}
+	}
+
+	sort.Slice(sheet, func(i, j int) bool { return sheet[i].Start.Before(sheet[j].Start) })
+	return sheet
+}
+
+// driftTags lists the session's drift categories and tags, once each
+func driftTags(e worklog.Entry) []string {
+	var tags []string
+	seen := map[string]bool{}
+	add := func(tag string) {
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, d := range e.Session.Drifts {
+		add(string(d.Category))
+		for _, tag := range d.Tags {
+			add(tag)
+		}
+	}
+	return tags
+}
+
+// WeekStart returns local midnight on the Monday of t's week
+func WeekStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	back := (int(t.Weekday()) + 6) % 7 // Days since Monday
+	return time.Date(y, m, d-back, 0, 0, 0, 0, t.Location())
+}
+
+// splitDays cuts an interval at each local midnight it crosses
+func splitDays(i worklog.Interval) []worklog.Interval {
+	var parts []worklog.Interval
+	for i.End.After(i.Start) {
+		y, m, d := i.Start.Date()
+		midnight := time.Date(y, m, d+1, 0, 0, 0, 0, i.Start.Location())
+		if !i.End.After(midnight) {
+			break
+		}
+		parts = append(parts, worklog.Interval{Start: i.Start, End: midnight})
+		i.Start = midnight
+	}
+	return append(parts, i)
+}
+
+// togglHeader is Toggl Track's CSV import layout
+var togglHeader = []string{
+	"Email", "Project", "Client", "Description", "Billable", "Start date", "Start time", "Duration", "Tags",
+}
+
+// TogglCSV writes entries in Toggl Track's CSV import format
+func TogglCSV(w io.Writer, sheet []Entry) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(togglHeader); err != nil {
+		return err
+	}
+
+	for _, e := range sheet {
+		row := []string{
+			e.Email,
+			e.Project,
+			e.Client,
+			e.Description,
+			yesNo(e.Billable),
+			e.Start.Format("2006-01-02"),
+			e.Start.Format("15:04:05"),
+			clock(e.Duration()),
+			strings.Join(e.Tags, ","),
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// clockifyHeader is Clockify's CSV import layout
+var clockifyHeader = []string{
+	"Project", "Client", "Description", "Email", "Tags", "Billable",
+	"Start Date", "Start Time", "End Date", "End Time", "Duration (h)",
+}
+
+// ClockifyCSV writes entries in Clockify's CSV import format
+func ClockifyCSV(w io.Writer, sheet []Entry) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(clockifyHeader); err != nil {
+		return err
+	}
+
+	for _, e := range sheet {
+		row := []string{
+			e.Project,
+			e.Client,
+			e.Description,
+			e.Email,
+			strings.Join(e.Tags, ", "),
+			yesNo(e.Billable),
+			e.Start.Format("2006-01-02"),
+			e.Start.Format("15:04:05"),
+			e.End.Format("2006-01-02"),
+			e.End.Format("15:04:05"),
+			clock(e.Duration()),
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// JSON writes the entries as a JSON array, the same body Push sends
+func JSON(w io.Writer, sheet []Entry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sheetOrEmpty(sheet))
+}
+
+// Push posts the entries as a JSON array to a timesheet API. Entry IDs are
+// stable, so the API can update entries it has already seen.
+func Push(api config.TimesheetAPI, sheet []Entry) error {
+	if api.URL == "" {
+		return fmt.Errorf("timesheet.api.url is not set")
+	}
+
+	body, err := json.Marshal(sheetOrEmpty(sheet))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, api.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range api.Headers {
+		req.Header.Set(key, value)
+	}
+	if api.TokenEnv != "" {
+		token := os.Getenv(api.TokenEnv)
+		if token == "" {
+			return fmt.Errorf("$%s is empty; set it to your API token", api.TokenEnv)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned %s: %s", api.URL, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// sheetOrEmpty makes an empty timesheet encode as [] rather than null
+func sheetOrEmpty(sheet []Entry) []Entry {
+	if sheet == nil {
+		return []Entry{}
+	}
+	return sheet
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// clock renders a duration as hh:mm:ss
+func clock(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
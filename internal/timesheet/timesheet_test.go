@@ -0,0 +1,280 @@
+package timesheet
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n3sty/focus/internal/config"
+	"github.com/n3sty/focus/internal/session"
+	"github.com/n3sty/focus/internal/worklog"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func at(loc *time.Location, day, hour, minute int) time.Time {
+	return time.Date(2026, 3, day, hour, minute, 0, 0, loc)
+}
+
+// fixture is two sessions: one worked in two stretches, one running past
+// midnight, with a sliver too short to log
+func fixture() []Entry {
+	parser := &session.Session{
+		ID:   "parser",
+		Task: `Fix the "quoted", comma parser`,
+		Drifts: []session.Drift{
+			{Category: session.CategoryMeeting, Tags: []string{"standup"}},
+			{Category: session.CategoryBug, Tags: []string{"standup", "ci"}},
+		},
+	}
+	release := &session.Session{ID: "release", Task: "Cut the release"}
+
+	return Build([]worklog.Entry{
+		{Session: release, Intervals: []worklog.Interval{
+			{Start: at(time.UTC, 2, 22, 30), End: at(time.UTC, 3, 1, 15)},
+		}},
+		{Session: parser, Intervals: []worklog.Interval{
+			{Start: at(time.UTC, 2, 9, 0), End: at(time.UTC, 2, 10, 30)},
+			{Start: at(time.UTC, 2, 11, 0), End: at(time.UTC, 2, 11, 0).Add(30 * time.Second)},
+			{Start: at(time.UTC, 2, 13, 5), End: at(time.UTC, 2, 13, 50).Add(20 * time.Second)},
+		}},
+	}, Options{Project: "Focus", Client: "Acme", Billable: true, Email: "ada@example.com"})
+}
+
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the golden file:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestTogglCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := TogglCSV(&buf, fixture()); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "toggl.csv", buf.Bytes())
+}
+
+func TestClockifyCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ClockifyCSV(&buf, fixture()); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "clockify.csv", buf.Bytes())
+}
+
+func TestBuild(t *testing.T) {
+	sheet := fixture()
+
+	var ids []string
+	for _, e := range sheet {
+		ids = append(ids, e.ID)
+	}
+	want := []string{"parser-1772442000", "parser-1772456700", "release-1772490600", "release-1772496000"}
+	if strings.Join(ids, " ") != strings.Join(want, " ") {
+		t.Errorf("IDs = %q, want %q: oldest first, the short sliver dropped", ids, want)
+	}
+	if tags := strings.Join(sheet[0].Tags, ","); tags != "meeting,standup,bug,ci" {
+		t.Errorf("tags = %q, want each category and tag once", tags)
+	}
+}
+
+func TestSplitDays(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		interval worklog.Interval
+		want     []time.Duration // Length of each part
+	}{
+		{
+			"same day",
+			worklog.Interval{Start: at(amsterdam, 2, 9, 0), End: at(amsterdam, 2, 17, 0)},
+			[]time.Duration{8 * time.Hour},
+		},
+		{
+			"ends at midnight",
+			worklog.Interval{Start: at(amsterdam, 2, 22, 0), End: at(amsterdam, 3, 0, 0)},
+			[]time.Duration{2 * time.Hour},
+		},
+		{
+			"across midnight",
+			worklog.Interval{Start: at(amsterdam, 2, 23, 0), End: at(amsterdam, 3, 1, 30)},
+			[]time.Duration{time.Hour, 90 * time.Minute},
+		},
+		{
+			// Clocks go forward at 2:00 on 29 March: that day is 23 hours
+			"over spring forward",
+			worklog.Interval{Start: at(amsterdam, 28, 22, 0), End: at(amsterdam, 30, 2, 0)},
+			[]time.Duration{2 * time.Hour, 23 * time.Hour, 2 * time.Hour},
+		},
+		{
+			// And back at 3:00 on 25 October: that day is 25 hours
+			"over fall back",
+			worklog.Interval{
+				Start: time.Date(2026, 10, 24, 23, 0, 0, 0, amsterdam),
+				End:   time.Date(2026, 10, 26, 1, 0, 0, 0, amsterdam),
+			},
+			[]time.Duration{time.Hour, 25 * time.Hour, time.Hour},
+		},
+		{
+			"empty",
+			worklog.Interval{Start: at(amsterdam, 2, 9, 0), End: at(amsterdam, 2, 9, 0)},
+			[]time.Duration{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := splitDays(tt.interval)
+
+			var got []time.Duration
+			for i, p := range parts {
+				got = append(got, p.Duration())
+				if i > 0 && (p.Start.Hour() != 0 || p.Start.Minute() != 0 || !p.Start.Equal(parts[i-1].End)) {
+					t.Errorf("part %d starts at %s, want local midnight where part %d ended", i, p.Start, i-1)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parts = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parts = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{"monday midnight", at(amsterdam, 2, 0, 0), at(amsterdam, 2, 0, 0)},
+		{"midweek", at(amsterdam, 4, 15, 30), at(amsterdam, 2, 0, 0)},
+		{"sunday night", at(amsterdam, 8, 23, 59), at(amsterdam, 2, 0, 0)},
+		{"across a month", at(amsterdam, 1, 12, 0), time.Date(2026, 2, 23, 0, 0, 0, 0, amsterdam)},
+		// Clocks went forward on Sunday 29 March
+		{"after spring forward", at(amsterdam, 29, 12, 0), at(amsterdam, 23, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekStart(tt.t); !got.Equal(tt.want) {
+				t.Errorf("WeekStart(%s) = %s, want %s", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPush(t *testing.T) {
+	var (
+		gotAuth, gotType, gotCustom string
+		gotEntries                  []Entry
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotType = r.Header.Get("Content-Type")
+		gotCustom = r.Header.Get("X-Workspace")
+		if err := json.NewDecoder(r.Body).Decode(&gotEntries); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	t.Setenv("FOCUS_TEST_TIMESHEET_TOKEN", "s3cret")
+	api := config.TimesheetAPI{
+		URL:      server.URL,
+		TokenEnv: "FOCUS_TEST_TIMESHEET_TOKEN",
+		Headers:  map[string]string{"X-Workspace": "acme"},
+	}
+
+	sheet := fixture()
+	if err := Push(api, sheet); err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAuth != "Bearer s3cret" {
+		t.Errorf("Authorization = %q, want the token from $FOCUS_TEST_TIMESHEET_TOKEN", gotAuth)
+	}
+	if gotType != "application/json" || gotCustom != "acme" {
+		t.Errorf("headers = %q, %q", gotType, gotCustom)
+	}
+	if len(gotEntries) != len(sheet) || gotEntries[0].ID != sheet[0].ID || !gotEntries[0].Start.Equal(sheet[0].Start) {
+		t.Errorf("pushed %+v, want %+v", gotEntries, sheet)
+	}
+}
+
+func TestPushEmpty(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("sent Authorization %q without a token_env", r.Header.Get("Authorization"))
+		}
+	}))
+	defer server.Close()
+
+	if err := Push(config.TimesheetAPI{URL: server.URL}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if body != "[]" {
+		t.Errorf("body = %q, want []", body)
+	}
+}
+
+func TestPushErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "  project \"Focus\" not found\n", http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	err := Push(config.TimesheetAPI{URL: server.URL}, fixture())
+	if err == nil || !strings.Contains(err.Error(), `422 Unprocessable Entity: project "Focus" not found`) {
+		t.Errorf("err = %v, want the status and response body", err)
+	}
+
+	t.Setenv("FOCUS_TEST_TIMESHEET_TOKEN", "")
+	err = Push(config.TimesheetAPI{URL: server.URL, TokenEnv: "FOCUS_TEST_TIMESHEET_TOKEN"}, fixture())
+	if err == nil || !strings.Contains(err.Error(), "$FOCUS_TEST_TIMESHEET_TOKEN is empty") {
+		t.Errorf("err = %v, want a missing token error", err)
+	}
+
+	if err := Push(config.TimesheetAPI{}, fixture()); err == nil {
+		t.Error("push without a URL succeeded")
+	}
+}
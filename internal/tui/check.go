@@ -2,21 +2,32 @@ package tui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/n3sty/focus/internal/git"
 	"github.com/n3sty/focus/internal/session"
 )
 
 type checkState int
 
 const (
-	stateQuestion checkState = iota
+	stateIdleReview checkState = iota
+	stateQuestion
+	stateStep
 	stateDriftDescription
+	stateDriftCategory
+	stateDriftCategoryOther
 	stateDriftReason
+	stateDriftMinutes
+	stateDriftSeverity
+	stateDriftTags
+	stateDriftPark
 	stateComplete
 )
 
@@ -24,16 +35,28 @@ type CheckModel struct {
 	session      *session.Session
 	state        checkState
 	textarea     textarea.Model
-	viewport     viewport.Model
+	viewport     viewport.Model // Session so far, toggled with t
+	showDetails  bool
 	Updated      bool
 	stillOnTrack bool
-	driftDesc    string
-	driftReason  string
+	drift        session.Drift
+	cursor       int // Selected row in the category and severity lists
+	park         bool
+	parkChanges  bool
+	hasChanges   bool  // Uncommitted changes that could be parked
+	stepsDone    []int // Steps checked off during this check
+	inputErr     string
+	switchedBack bool
+	answer       string
+	note         string // Attached to the check-in
+	idleReviews  []bool
+	switchErr    error
 	width        int
 	height       int
 }
 
-func NewCheckModel(sess *session.Session) CheckModel {
+// NewCheckModel creates a check-in for sess; note is stored with the answer
+func NewCheckModel(sess *session.Session, note string) CheckModel {
 	ta := textarea.New()
 	ta.Placeholder = "Type here..."
 	ta.Focus()
@@ -41,17 +64,58 @@ func NewCheckModel(sess *session.Session) CheckModel {
 	ta.SetWidth(60)
 	ta.SetHeight(3)
 
-	vp := viewport.New(80, 20)
+	vp := viewport.New(80, 8)
+
+	// Ask about idle time the watcher paused before the regular check
+	state := stateQuestion
+	if sess.UnreviewedIdle() != nil {
+		state = stateIdleReview
+	}
 
 	return CheckModel{
 		session:  sess,
-		state:    stateQuestion,
+		state:    state,
 		textarea: ta,
 		viewport: vp,
+		note:     note,
 		Updated:  false,
 	}
 }
 
+// checkIn stores the answer on the session and finishes the check
+func (m CheckModel) checkIn(answer string) (tea.Model, tea.Cmd) {
+	m.answer = answer
+	m.session.AddCheckIn(answer, m.note, "check", time.Now())
+	if step := m.session.CurrentStep(); step >= 0 {
+		m.session.LastCheckIn().Step = m.session.Steps[step].Title
+	}
+	m.Updated = true
+	m.state = stateComplete
+	return m, tea.Quit
+}
+
+// Answer returns how the check was answered: "yes", "no" (drift logged),
+// "defer", "switch" (back to the focus branch), or "" if cancelled
+func (m CheckModel) Answer() string {
+	return m.answer
+}
+
+// Park reports whether the user wants the drift parked as its own paused
+// session, and whether to take uncommitted changes along
+func (m CheckModel) Park() (park, withChanges bool) {
+	return m.park, m.parkChanges
+}
+
+// StepsDone returns the steps (0-based) checked off during the check
+func (m CheckModel) StepsDone() []int {
+	return m.stepsDone
+}
+
+// IdleReviews returns the user's answers to "was that a break?", in order
+func (m CheckModel) IdleReviews() []bool {
+	return m.idleReviews
+}
+
 func (m CheckModel) Init() tea.Cmd {
 	return textarea.Blink
 }
@@ -64,7 +128,7 @@ func (m CheckModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.viewport.Width = msg.Width - 4
-		m.viewport.Height = msg.Height - 10
+		m.viewport.Height = max(msg.Height-22, 5)
 
 	case tea.KeyMsg:
 		switch msg.Type {
@@ -77,9 +141,17 @@ func (m CheckModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		default:
 			// Handle state-specific key bindings
 			switch m.state {
+			case stateIdleReview:
+				return m.handleIdleKeys(msg)
 			case stateQuestion:
 				return m.handleQuestionKeys(msg)
-			case stateDriftDescription, stateDriftReason:
+			case stateDriftCategory, stateDriftSeverity:
+				return m.handleListKeys(msg)
+			case stateDriftPark:
+				return m.handleParkKeys(msg)
+			case stateStep:
+				return m.handleStepKeys(msg)
+			case stateDriftDescription, stateDriftCategoryOther, stateDriftReason, stateDriftMinutes, stateDriftTags:
 				m.textarea, cmd = m.textarea.Update(msg)
 				return m, cmd
 			case stateComplete:
@@ -91,12 +163,36 @@ func (m CheckModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m CheckModel) handleIdleKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "b", "B":
+		m.session.ReviewIdle(true)
+		m.idleReviews = append(m.idleReviews, true)
+	case "w", "W":
+		m.session.ReviewIdle(false)
+		m.idleReviews = append(m.idleReviews, false)
+	default:
+		return m, nil
+	}
+
+	m.Updated = true
+	if m.session.UnreviewedIdle() == nil {
+		m.state = stateQuestion
+	}
+	return m, nil
+}
+
 func (m CheckModel) handleQuestionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
 		m.stillOnTrack = true
-		m.state = stateComplete
-		return m, tea.Quit
+		if step := m.session.CurrentStep(); step >= 0 {
+			// Ask which step before finishing
+			m.state = stateStep
+			m.cursor = step
+			return m, nil
+		}
+		return m.checkIn("yes")
 	case "n", "N":
 		m.stillOnTrack = false
 		m.state = stateDriftDescription
@@ -104,31 +200,206 @@ func (m CheckModel) handleQuestionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.textarea.Placeholder = "What are you working on instead?"
 		return m, nil
 	case "d", "D":
-		// Defer - skip for now without logging
-		m.state = stateComplete
-		return m, tea.Quit
+		// Defer - nothing to log beyond the check-in itself
+		return m.checkIn("defer")
+	case "s", "S":
+		if m.session.OffBranch == nil {
+			return m, nil
+		}
+		// Switch back to the focus branch; time away becomes a drift
+		if err := git.SwitchBranch(m.session.Branch); err != nil {
+			m.switchErr = err
+			return m, nil
+		}
+		m.session.TrackBranch(m.session.Branch, time.Now())
+		m.switchedBack = true
+		m.stillOnTrack = true
+		return m.checkIn("switch")
+	case "t", "T":
+		// Same details as 'focus timeline', to help answer honestly
+		m.showDetails = !m.showDetails
+		if m.showDetails {
+			m.viewport.SetContent(NewTimelineModel(m.session, nil, nil, time.Now()).renderDetails())
+			m.viewport.GotoBottom()
+		}
+		return m, nil
+	}
+
+	if m.showDetails {
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// categoryChoices are the rows of the category list; the last one asks
+// for a category in the user's own words
+func categoryChoices() []string {
+	choices := make([]string, 0, len(session.Categories)+1)
+	for _, c := range session.Categories {
+		choices = append(choices, c.Label())
+	}
+	return append(choices, "Something else...")
+}
+
+// severities are the rows of the severity list
+var severities = []session.Severity{session.SeverityLow, session.SeverityMedium, session.SeverityHigh}
+
+func (m CheckModel) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := len(severities)
+	if m.state == stateDriftCategory {
+		rows = len(session.Categories) + 1
+	}
+
+	switch msg.String() {
+	case "up", "k", "shift+tab":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j", "tab":
+		if m.cursor < rows-1 {
+			m.cursor++
+		}
+	case "l", "L":
+		if m.state == stateDriftSeverity {
+			m.cursor = 0
+			return m.handleEnter()
+		}
+	case "m", "M":
+		if m.state == stateDriftSeverity {
+			m.cursor = 1
+			return m.handleEnter()
+		}
+	case "h", "H":
+		if m.state == stateDriftSeverity {
+			m.cursor = 2
+			return m.handleEnter()
+		}
 	}
 	return m, nil
 }
 
+func (m CheckModel) handleStepKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k", "shift+tab":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j", "tab":
+		if m.cursor < len(m.session.Steps)-1 {
+			m.cursor++
+		}
+	case "x", " ":
+		// Check off the selected step and move to the next open one
+		if err := m.session.CompleteStep(m.cursor, time.Now()); err != nil {
+			return m, nil
+		}
+		m.stepsDone = append(m.stepsDone, m.cursor)
+		m.Updated = true
+		if next := m.session.NextStep(); next >= 0 {
+			m.cursor = next
+		} else {
+			return m.checkIn("yes")
+		}
+	}
+	return m, nil
+}
+
+func (m CheckModel) handleParkKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "p", "P":
+		m.park = true
+	case "c", "C":
+		if !m.hasChanges {
+			return m, nil
+		}
+		m.park = true
+		m.parkChanges = true
+	case "k", "K":
+	default:
+		return m, nil
+	}
+	return m.logDrift()
+}
+
+// logDrift saves the finished drift and answers the check
+func (m CheckModel) logDrift() (tea.Model, tea.Cmd) {
+	m.session.LogDrift(m.drift)
+	return m.checkIn("no")
+}
+
+// prompt moves to a free-text step
+func (m CheckModel) prompt(state checkState, placeholder string) CheckModel {
+	m.state = state
+	m.inputErr = ""
+	m.textarea.Reset()
+	m.textarea.Placeholder = placeholder
+	return m
+}
+
 func (m CheckModel) handleEnter() (tea.Model, tea.Cmd) {
+	value := strings.TrimSpace(m.textarea.Value())
+
 	switch m.state {
+	case stateStep:
+		if !m.session.Steps[m.cursor].Done() {
+			m.session.ActiveStep = m.cursor
+		}
+		return m.checkIn("yes")
+
 	case stateDriftDescription:
-		m.driftDesc = strings.TrimSpace(m.textarea.Value())
-		if m.driftDesc == "" {
+		if value == "" {
 			return m, nil
 		}
-		m.state = stateDriftReason
-		m.textarea.Reset()
-		m.textarea.Placeholder = "Why is this necessary? (optional, press Enter to skip)"
+		m.drift.Description = value
+		m.state = stateDriftCategory
+		m.cursor = 0
 		return m, nil
 
+	case stateDriftCategory:
+		if m.cursor == len(session.Categories) {
+			return m.prompt(stateDriftCategoryOther, "e.g. code review, support request"), nil
+		}
+		m.drift.Category = session.Categories[m.cursor]
+		return m.prompt(stateDriftReason, "Why is this necessary? (optional, press Enter to skip)"), nil
+
+	case stateDriftCategoryOther:
+		if value == "" {
+			return m, nil
+		}
+		m.drift.Category = session.Category(strings.ToLower(value))
+		return m.prompt(stateDriftReason, "Why is this necessary? (optional, press Enter to skip)"), nil
+
 	case stateDriftReason:
-		m.driftReason = strings.TrimSpace(m.textarea.Value())
-		m.session.AddDrift(m.driftDesc, m.driftReason)
-		m.Updated = true
-		m.state = stateComplete
-		return m, tea.Quit
+		m.drift.Reason = value
+		return m.prompt(stateDriftMinutes, "Minutes lost, e.g. 20 (optional, press Enter to skip)"), nil
+
+	case stateDriftMinutes:
+		if value != "" {
+			minutes, err := strconv.Atoi(strings.TrimSuffix(value, "m"))
+			if err != nil || minutes < 0 {
+				m.inputErr = fmt.Sprintf("%q isn't a number of minutes", value)
+				return m, nil
+			}
+			m.drift.MinutesLost = minutes
+		}
+		m.state = stateDriftSeverity
+		m.cursor = 1 // Medium
+		return m, nil
+
+	case stateDriftSeverity:
+		m.drift.Severity = severities[m.cursor]
+		return m.prompt(stateDriftTags, "Tags, comma separated (optional, press Enter to skip)"), nil
+
+	case stateDriftTags:
+		m.drift.Tags = session.ParseTags(value)
+		m.hasChanges = git.HasChanges()
+		m.state = stateDriftPark
+		return m, nil
+
+	case stateDriftPark:
+		return m.logDrift()
 	}
 	return m, nil
 }
@@ -148,12 +419,28 @@ func (m CheckModel) View() string {
 
 	// State-specific content
 	switch m.state {
+	case stateIdleReview:
+		b.WriteString(m.renderIdleReview())
 	case stateQuestion:
 		b.WriteString(m.renderQuestion())
+	case stateStep:
+		b.WriteString(m.renderStep())
 	case stateDriftDescription:
 		b.WriteString(m.renderDriftDescription())
+	case stateDriftCategory:
+		b.WriteString(m.renderDriftCategory())
+	case stateDriftCategoryOther:
+		b.WriteString(m.renderInput(fmt.Sprintf("%s What kind of rabbit hole is it?", EmojiDrift), ""))
 	case stateDriftReason:
 		b.WriteString(m.renderDriftReason())
+	case stateDriftMinutes:
+		b.WriteString(m.renderInput(fmt.Sprintf("%s Roughly how many minutes has it cost you?", EmojiTime), ""))
+	case stateDriftSeverity:
+		b.WriteString(m.renderDriftSeverity())
+	case stateDriftTags:
+		b.WriteString(m.renderInput(fmt.Sprintf("%s Any tags?", EmojiPin), "Tags make it easier to spot patterns in 'focus stats'."))
+	case stateDriftPark:
+		b.WriteString(m.renderDriftPark())
 	case stateComplete:
 		b.WriteString(m.renderComplete())
 	}
@@ -172,12 +459,70 @@ func (m CheckModel) renderQuestion() string {
 	b.WriteString(question)
 	b.WriteString("\n\n")
 
+	if off := m.session.OffBranch; off != nil {
+		warning := fmt.Sprintf("%s You're on '%s', not %s (since %s)",
+			EmojiWarning, off.Branch, m.session.Branch, off.Since.Format("15:04"))
+		b.WriteString(WarningStyle.Render(warning))
+		b.WriteString("\n\n")
+	}
+
 	options := []string{
 		SuccessStyle.Render("[y] Yes") + " - Still on track!",
 		WarningStyle.Render("[n] No") + "  - I've drifted...",
 		MutedStyle.Render("[d] Defer") + " - Check me later",
 	}
 
+	if m.session.OffBranch != nil {
+		options = append(options, InfoStyle.Render("[s] Switch")+" - Back to "+m.session.Branch)
+	}
+
+	b.WriteString(strings.Join(options, "\n"))
+	b.WriteString("\n\n")
+
+	if m.switchErr != nil {
+		b.WriteString(WarningStyle.Render(fmt.Sprintf("%s %v", EmojiWarning, m.switchErr)))
+		b.WriteString("\n\n")
+	}
+
+	if m.showDetails {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Session so far"))
+		b.WriteString("\n")
+		b.WriteString(m.viewport.View())
+		b.WriteString("\n\n")
+		b.WriteString(HintStyle.Render("t to hide the session • ↑/↓ to scroll • Esc to cancel"))
+		return b.String()
+	}
+
+	b.WriteString(HintStyle.Render("t to show the session so far • Esc to cancel"))
+
+	return b.String()
+}
+
+func (m CheckModel) renderIdleReview() string {
+	var b strings.Builder
+
+	idle := m.session.UnreviewedIdle()
+	if idle == nil {
+		return ""
+	}
+
+	prompt := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorInfo).
+		Render(fmt.Sprintf("%s You were idle from %s to %s (%s). Was that a break?",
+			EmojiIdle,
+			idle.Start.Format("15:04"),
+			idle.End.Format("15:04"),
+			formatDuration(idle.Duration(time.Now()))))
+
+	b.WriteString(prompt)
+	b.WriteString("\n\n")
+
+	options := []string{
+		MutedStyle.Render("[b] Break") + " - Don't count it as focused time",
+		SuccessStyle.Render("[w] Work") + "  - I was thinking, reading or on a call",
+	}
+
 	b.WriteString(strings.Join(options, "\n"))
 	b.WriteString("\n\n")
 	b.WriteString(HintStyle.Render("Press Esc to cancel"))
@@ -219,7 +564,112 @@ func (m CheckModel) renderDriftReason() string {
 	return b.String()
 }
 
+func (m CheckModel) renderStep() string {
+	prompt := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorInfo).
+		Render("Which step are you on?")
+
+	done, total := m.session.StepProgress()
+	progress := MutedStyle.Render(fmt.Sprintf("%d of %d steps done", done, total))
+
+	choices := make([]string, len(m.session.Steps))
+	for i, step := range m.session.Steps {
+		choices[i] = "○ " + step.Title
+		if step.Done() {
+			choices[i] = SuccessStyle.Render("✓") + " " + MutedStyle.Render(step.Title)
+		}
+	}
+
+	return prompt + "\n" + progress + "\n\n" + renderChoices(choices, m.cursor) + "\n" +
+		HintStyle.Render("↑/↓ to select • x to mark done • Enter to confirm • Esc to cancel")
+}
+
+func (m CheckModel) renderDriftCategory() string {
+	prompt := lipgloss.NewStyle().
+		Foreground(ColorWarning).
+		Render(fmt.Sprintf("%s What kind of drift is \"%s\"?", EmojiDrift, m.drift.Description))
+
+	return prompt + "\n\n" + renderChoices(categoryChoices(), m.cursor) + "\n" +
+		HintStyle.Render("↑/↓ to select • Enter to confirm • Esc to cancel")
+}
+
+func (m CheckModel) renderDriftSeverity() string {
+	prompt := lipgloss.NewStyle().
+		Foreground(ColorInfo).
+		Render(fmt.Sprintf("%s How badly did it throw you off?", EmojiThink))
+
+	choices := []string{"[l] Low - a quick detour", "[m] Medium - lost the thread for a while", "[h] High - the goal is at risk"}
+	return prompt + "\n\n" + renderChoices(choices, m.cursor) + "\n" +
+		HintStyle.Render("↑/↓ or l/m/h to select • Enter to confirm • Esc to cancel")
+}
+
+func (m CheckModel) renderDriftPark() string {
+	var b strings.Builder
+
+	prompt := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorInfo).
+		Render(fmt.Sprintf("%s Park \"%s\" for later?", EmojiPin, m.drift.Description))
+
+	b.WriteString(prompt)
+	b.WriteString("\n\n")
+	b.WriteString(MutedStyle.Render("Parking creates a paused session with its own branch off main, so you can get straight back to your goal."))
+	b.WriteString("\n\n")
+
+	options := []string{InfoStyle.Render("[p] Park it") + "  - New paused session, work tree untouched"}
+	if m.hasChanges {
+		options = append(options, InfoStyle.Render("[c] Carry")+"    - Park it and stash my uncommitted changes with it")
+	}
+	options = append(options, MutedStyle.Render("[k] Keep")+"     - Just log the drift")
+
+	b.WriteString(strings.Join(options, "\n"))
+	b.WriteString("\n\n")
+	b.WriteString(HintStyle.Render("Press Enter to just log it, Esc to cancel"))
+
+	return b.String()
+}
+
+// renderInput shows a free-text step with an optional explanation
+func (m CheckModel) renderInput(question, help string) string {
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().Foreground(ColorInfo).Render(question))
+	b.WriteString("\n\n")
+	if help != "" {
+		b.WriteString(MutedStyle.Render(help))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.textarea.View())
+	b.WriteString("\n\n")
+	if m.inputErr != "" {
+		b.WriteString(WarningStyle.Render(fmt.Sprintf("%s %s", EmojiWarning, m.inputErr)))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(HintStyle.Render("Press Enter to continue, Esc to cancel"))
+
+	return b.String()
+}
+
+// renderChoices draws a list with a cursor on the selected row
+func renderChoices(choices []string, selected int) string {
+	var b strings.Builder
+	for i, choice := range choices {
+		if i == selected {
+			b.WriteString(lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true).Render("▸ " + choice))
+		} else {
+			b.WriteString("  " + choice)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 func (m CheckModel) renderComplete() string {
+	if m.switchedBack {
+		return SuccessStyle.Render(fmt.Sprintf("%s Switched back to %s. Time away logged as a drift.", EmojiSuccess, m.session.Branch), "\n\n")
+	}
+
 	if m.stillOnTrack {
 		return SuccessStyle.Render(fmt.Sprintf("%s Great! Keep going.", EmojiSuccess), "\n\n")
 	}
@@ -227,10 +677,16 @@ func (m CheckModel) renderComplete() string {
 	var b strings.Builder
 	b.WriteString(WarningStyle.Render(fmt.Sprintf("%s Drift logged.", EmojiDrift)))
 	b.WriteString("\n\n")
+
+	if m.park {
+		b.WriteString(InfoStyle.Render(fmt.Sprintf("%s Parking it for later. Back to: %s", EmojiPin, m.session.Task)))
+		b.WriteString("\n")
+		return b.String()
+	}
+
 	b.WriteString(InfoStyle.Render("Consider:"))
 	b.WriteString("\n")
 	b.WriteString("  • git stash (save current work)\n")
 	b.WriteString("  • git checkout main (return to main branch)\n")
-	b.WriteString("  • Or create a new focus session for this work\n")
 	return b.String()
 }
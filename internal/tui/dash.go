@@ -0,0 +1,349 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/session"
+)
+
+// DashAction is what the user asked the dashboard to do on exit
+type DashAction int
+
+const (
+	DashQuit DashAction = iota
+	DashCheck
+	DashExtend
+	DashPause
+	DashEnd
+)
+
+// DashSnapshot is everything the dashboard shows, loaded fresh on refresh
+type DashSnapshot struct {
+	Session  *session.Session
+	Commits  []git.Commit
+	Watching bool // Background watcher is running
+}
+
+// How often the dashboard redraws and how often it reloads commits and
+// watcher state when the session file hasn't changed
+const (
+	dashTick    = time.Second
+	dashRefresh = 15 * time.Second
+)
+
+// dashListSize is how many commits and drifts are shown
+const dashListSize = 5
+
+type dashTickMsg time.Time
+
+type dashLoadedMsg struct {
+	snapshot DashSnapshot
+	modTime  time.Time
+	err      error
+}
+
+type DashModel struct {
+	load     func() (DashSnapshot, error)
+	snapshot DashSnapshot
+	err      error
+	modTime  time.Time // Session file time at the last load
+	loadedAt time.Time
+	loading  bool
+	now      time.Time
+	bar      progress.Model
+	action   DashAction
+	width    int
+	height   int
+}
+
+// NewDashModel creates a dashboard starting from snapshot. load is called
+// again on every refresh.
+func NewDashModel(snapshot DashSnapshot, load func() (DashSnapshot, error)) DashModel {
+	now := time.Now()
+	modTime, _ := session.ModTime(snapshot.Session.ID)
+
+	return DashModel{
+		load:     load,
+		snapshot: snapshot,
+		modTime:  modTime,
+		loadedAt: now,
+		now:      now,
+		bar:      progress.New(progress.WithSolidFill(string(ColorSuccess)), progress.WithWidth(40)),
+	}
+}
+
+// Action returns the key binding the dashboard was closed with
+func (m DashModel) Action() DashAction {
+	return m.action
+}
+
+func (m DashModel) Init() tea.Cmd {
+	return dashTickCmd()
+}
+
+func dashTickCmd() tea.Cmd {
+	return tea.Tick(dashTick, func(t time.Time) tea.Msg { return dashTickMsg(t) })
+}
+
+// reload fetches a new snapshot in the background
+func (m DashModel) reload() tea.Cmd {
+	load := m.load
+	return func() tea.Msg {
+		snapshot, err := load()
+		msg := dashLoadedMsg{snapshot: snapshot, err: err}
+		if err == nil {
+			msg.modTime, _ = session.ModTime(snapshot.Session.ID)
+		}
+		return msg
+	}
+}
+
+func (m DashModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.bar.Width = min(max(msg.Width-30, 20), 60)
+		return m, nil
+
+	case dashTickMsg:
+		m.now = time.Time(msg)
+		cmds := []tea.Cmd{dashTickCmd()}
+		if !m.loading && m.stale() {
+			m.loading = true
+			cmds = append(cmds, m.reload())
+		}
+		return m, tea.Batch(cmds...)
+
+	case dashLoadedMsg:
+		m.loading = false
+		m.loadedAt = m.now
+		m.err = msg.err
+		if msg.err == nil {
+			m.snapshot = msg.snapshot
+			m.modTime = msg.modTime
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.action = DashQuit
+		case "c":
+			m.action = DashCheck
+		case "e":
+			m.action = DashExtend
+		case "p":
+			m.action = DashPause
+		case "x":
+			m.action = DashEnd
+		case "r":
+			if !m.loading {
+				m.loading = true
+				return m, m.reload()
+			}
+			return m, nil
+		default:
+			return m, nil
+		}
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+// stale reports whether the snapshot should be reloaded: the session file
+// changed on disk, or the periodic refresh is due
+func (m DashModel) stale() bool {
+	if m.now.Sub(m.loadedAt) >= dashRefresh {
+		return true
+	}
+	modTime, err := session.ModTime(m.snapshot.Session.ID)
+	return err != nil || !modTime.Equal(m.modTime)
+}
+
+func (m DashModel) View() string {
+	sess := m.snapshot.Session
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render("📺 Focus Dashboard"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s  %s\n", EmojiGoal, sess.Task))
+	b.WriteString(MutedStyle.Render(fmt.Sprintf("%s • started %s", sess.Branch, sess.StartTime.Format("15:04"))))
+	b.WriteString("\n")
+	if done, total := sess.StepProgress(); total > 0 {
+		if step := sess.CurrentStep(); step >= 0 {
+			b.WriteString(fmt.Sprintf("📋  Step %d of %d: %s (%d done)\n", step+1, total, sess.Steps[step].Title, done))
+		} else {
+			b.WriteString(SuccessStyle.Render(fmt.Sprintf("📋  All %d steps done", total)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.renderTimebox())
+	b.WriteString("\n\n")
+	b.WriteString(m.renderWatcher())
+	b.WriteString("\n\n")
+
+	heading := lipgloss.NewStyle().Bold(true)
+	b.WriteString(heading.Render(fmt.Sprintf("%s Latest Commits", EmojiCommit)))
+	b.WriteString("\n")
+	b.WriteString(m.renderCommits())
+	b.WriteString("\n")
+	b.WriteString(heading.Render(fmt.Sprintf("%s Drift Log", EmojiDrift)))
+	b.WriteString("\n")
+	b.WriteString(m.renderDrifts())
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(WarningStyle.Render(fmt.Sprintf("%s Could not refresh: %v", EmojiWarning, m.err)))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HintStyle.Render("c check • e extend +15m • p pause • x end • r refresh • q quit"))
+
+	return BaseStyle.Render(b.String())
+}
+
+// renderTimebox draws the countdown to expiry with a progress bar
+func (m DashModel) renderTimebox() string {
+	sess := m.snapshot.Session
+	focused := sess.FocusedTime(m.now)
+	elapsed := m.now.Sub(sess.StartTime)
+
+	var b strings.Builder
+
+	timebox, err := time.ParseDuration(sess.TimeBox)
+	if err != nil || timebox <= 0 {
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("No valid timebox (%q)", sess.TimeBox)))
+	} else {
+		remaining := timebox - focused
+		percent := float64(focused) / float64(timebox)
+
+		bar := m.bar
+		switch {
+		case remaining <= 0:
+			bar.FullColor = string(ColorDanger)
+		case percent >= 0.8:
+			bar.FullColor = string(ColorWarning)
+		}
+
+		b.WriteString(bar.ViewAs(min(percent, 1)))
+		b.WriteString("  ")
+		if remaining > 0 {
+			b.WriteString(InfoStyle.Render(fmt.Sprintf("%s %s left of %s", EmojiTime, formatClock(remaining), sess.TimeBox)))
+		} else {
+			b.WriteString(lipgloss.NewStyle().Foreground(ColorDanger).Bold(true).
+				Render(fmt.Sprintf("%s over by %s", EmojiTime, formatClock(-remaining))))
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("Focused %s of %s elapsed", formatClock(focused), formatClock(elapsed)))
+
+	if pomo := sess.Pomodoro; pomo != nil {
+		icon := "🍅"
+		if pomo.Phase.IsBreak() {
+			icon = "☕"
+		}
+		b.WriteString(fmt.Sprintf("\n%s %s - %s left (%d done)", icon, pomo.Phase.Label(), formatClock(pomo.Remaining(m.now)), pomo.Completed))
+	}
+
+	return b.String()
+}
+
+// renderWatcher shows whether the watcher is running and anything holding
+// back reminders or focused time
+func (m DashModel) renderWatcher() string {
+	sess := m.snapshot.Session
+	var lines []string
+
+	if m.snapshot.Watching {
+		lines = append(lines, SuccessStyle.Render("●")+" Watcher running")
+	} else {
+		lines = append(lines, MutedStyle.Render("○ Watcher stopped - no reminders will be sent"))
+	}
+
+	if sess.SnoozedUntil != nil && m.now.Before(*sess.SnoozedUntil) {
+		lines = append(lines, fmt.Sprintf("🔕 Reminders snoozed until %s", sess.SnoozedUntil.Format("15:04")))
+	}
+
+	if meeting := sess.OpenPause(); meeting != nil && meeting.Reason == "meeting" {
+		lines = append(lines, fmt.Sprintf("📅 In a meeting since %s - focused time is paused", meeting.Start.Format("15:04")))
+	}
+
+	if idle := sess.OpenPause(); idle != nil && idle.Reason == "idle" {
+		lines = append(lines, fmt.Sprintf("%s Idle since %s - focused time is paused", EmojiIdle, idle.Start.Format("15:04")))
+	} else if sess.UnreviewedIdle() != nil {
+		lines = append(lines, fmt.Sprintf("%s Idle time to review - press c", EmojiIdle))
+	}
+
+	if off := sess.OffBranch; off != nil {
+		lines = append(lines, WarningStyle.Render(fmt.Sprintf("%s On '%s', not %s (for %s)",
+			EmojiWarning, off.Branch, sess.Branch, formatDuration(m.now.Sub(off.Since)))))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderCommits lists the newest commits first
+func (m DashModel) renderCommits() string {
+	commits := m.snapshot.Commits
+	if len(commits) == 0 {
+		return MutedStyle.Render("  No commits yet.") + "\n"
+	}
+
+	var b strings.Builder
+	for i := len(commits) - 1; i >= max(len(commits)-dashListSize, 0); i-- {
+		c := commits[i]
+		b.WriteString(fmt.Sprintf("  %s %s %s\n",
+			MutedStyle.Render(c.Time.Format("15:04")), InfoStyle.Render(c.Hash), c.Subject))
+	}
+	if len(commits) > dashListSize {
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("  …and %d more", len(commits)-dashListSize)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderDrifts lists the most recent drifts, oldest first
+func (m DashModel) renderDrifts() string {
+	drifts := m.snapshot.Session.Drifts
+	if len(drifts) == 0 {
+		return MutedStyle.Render("  No drifts. Nice.") + "\n"
+	}
+
+	var b strings.Builder
+	if len(drifts) > dashListSize {
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("  …%d earlier", len(drifts)-dashListSize)))
+		b.WriteString("\n")
+	}
+	for _, d := range drifts[max(len(drifts)-dashListSize, 0):] {
+		b.WriteString(fmt.Sprintf("  %s %s", MutedStyle.Render(d.Timestamp.Format("15:04")), d.Description))
+		if d.Reason != "" {
+			b.WriteString(MutedStyle.Render(fmt.Sprintf(" (%s)", d.Reason)))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatClock renders a duration as h:mm:ss, or mm:ss under an hour
+func formatClock(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := int(d.Hours())
+	mins := int(d.Minutes()) % 60
+	secs := int(d.Seconds()) % 60
+
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, mins, secs)
+	}
+	return fmt.Sprintf("%02d:%02d", mins, secs)
+}
@@ -1,12 +1,17 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/n3sty/focus/internal/acceptance"
 	"github.com/n3sty/focus/internal/git"
 	"github.com/n3sty/focus/internal/session"
 )
@@ -19,13 +24,82 @@ const (
 	actionAbandon
 )
 
+// actionNames names the end actions for hooks and reflections
+var actionNames = []string{"merge", "pause", "discard"}
+
+// reflectionQuestions are asked in order before the rating
+var reflectionQuestions = []string{
+	"What got done?",
+	"What blocked you?",
+	"What did you learn?",
+}
+
+// ratingLabels describe the focus self-rating, 1 to 5
+var ratingLabels = []string{"Scattered", "Distracted", "Okay", "Focused", "In the zone"}
+
 type EndModel struct {
-	session    *session.Session
-	selected   int
-	commits    int
-	elapsed    time.Duration
-	choice     endAction
-	confirmed  bool
+	session     *session.Session
+	selected    int
+	commits     int
+	elapsed     time.Duration
+	choice      endAction
+	confirmed   bool
+	recommended endAction
+	advice      string  // Why the recommendation isn't merging
+	verifying   int     // Criterion whose command is running, -1 when idle
+	checks      *checks // Runs the commands; shared by copies of the model
+	blocked     bool    // Tried to merge before the criteria were met
+
+	reflect    bool // Ask reflection questions after the action is picked
+	reflecting bool
+	question   int // Reflection question being asked; len(reflectionQuestions) is the rating
+	textarea   textarea.Model
+	reflection session.Reflection
+}
+
+// checks runs criterion commands until the model is done with them.
+// Copies of the model share it, so StopChecks reaches the running one.
+type checks struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	running chan struct{} // Closed when the latest command returns
+}
+
+func newChecks() *checks {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &checks{ctx: ctx, cancel: cancel}
+}
+
+// run executes command, killing it if the checks are stopped
+func (c *checks) run(command string) session.CriterionResult {
+	done := make(chan struct{})
+	defer close(done)
+
+	c.mu.Lock()
+	c.running = done
+	c.mu.Unlock()
+
+	return acceptance.Run(c.ctx, command)
+}
+
+// stop kills the running command and waits for it to exit
+func (c *checks) stop() {
+	c.cancel()
+
+	c.mu.Lock()
+	running := c.running
+	c.mu.Unlock()
+	if running != nil {
+		<-running
+	}
+}
+
+// criterionDoneMsg carries the result of one criterion's command
+type criterionDoneMsg struct {
+	index  int
+	result session.CriterionResult
 }
 
 // GetChoice returns the user's choice (0=merge, 1=continue, 2=abandon)
@@ -33,26 +107,151 @@ func (m EndModel) GetChoice() int {
 	return int(m.choice)
 }
 
-func NewEndModel(sess *session.Session) EndModel {
-	commits, _ := git.GetCommitsSince(sess.StartTime)
-	elapsed := time.Since(sess.StartTime)
+// ActionName returns the chosen action: "merge", "pause" or "discard"
+func (m EndModel) ActionName() string {
+	return actionNames[m.choice]
+}
+
+// Reflection returns what the user wrote down before the action, or nil
+// if they skipped it
+func (m EndModel) Reflection() *session.Reflection {
+	if !m.confirmed || m.reflection.Empty() {
+		return nil
+	}
+	return &m.reflection
+}
+
+// Confirmed reports whether the user picked an action rather than cancelling
+func (m EndModel) Confirmed() bool {
+	return m.confirmed
+}
+
+// StopChecks kills a criterion command that is still running when the
+// program quits, and waits for it to exit
+func (m EndModel) StopChecks() {
+	m.checks.stop()
+}
+
+// NewEndModel creates the end review for sess. With reflect set, picking
+// an action first asks what got done, what blocked and what was learned.
+func NewEndModel(sess *session.Session, reflect bool) EndModel {
+	commits, _ := git.GetCommitsSince(sess.Branch, sess.StartTime)
+	elapsed := sess.FocusedTime(time.Now())
+
+	ta := textarea.New()
+	ta.Placeholder = "Optional - press Enter to skip"
+	ta.CharLimit = 500
+	ta.SetWidth(60)
+	ta.SetHeight(3)
 
-	return EndModel{
+	m := EndModel{
 		session:   sess,
 		selected:  0,
 		commits:   commits,
 		elapsed:   elapsed,
 		confirmed: false,
+		reflect:   reflect,
+		textarea:  ta,
+		checks:    newChecks(),
 	}
+
+	// Results from an earlier 'focus end' are stale; run everything again
+	for i := range sess.Criteria {
+		sess.Criteria[i].Result = nil
+	}
+	m.verifying = m.nextCommand(0)
+
+	m.recommend()
+	m.selected = int(m.recommended)
+	return m
+}
+
+// recommend suggests pausing instead of merging while the checklist has
+// open steps or the definition of done isn't met
+func (m *EndModel) recommend() {
+	m.recommended = actionMerge
+	m.advice = ""
+
+	var open []string
+	if done, total := m.session.StepProgress(); done < total {
+		open = append(open, fmt.Sprintf("%d of %d steps still open", total-done, total))
+	}
+	if met, total := m.session.CriteriaMet(); met < total {
+		open = append(open, fmt.Sprintf("%d of %d criteria not met", total-met, total))
+	}
+
+	if len(open) > 0 {
+		m.recommended = actionContinue
+		m.advice = strings.Join(open, ", ")
+	}
+}
+
+// canMerge reports whether every acceptance criterion is met
+func (m EndModel) canMerge() bool {
+	met, total := m.session.CriteriaMet()
+	return m.verifying < 0 && met == total
 }
 
 func (m EndModel) Init() tea.Cmd {
-	return nil
+	return m.verify(m.verifying)
+}
+
+// nextCommand returns the first criterion at or after i with a command to
+// run, or -1. Commands run one at a time so test suites don't compete for
+// the machine.
+func (m EndModel) nextCommand(i int) int {
+	for ; i < len(m.session.Criteria); i++ {
+		if m.session.Criteria[i].Command != "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// verify runs criterion i's command in the background
+func (m EndModel) verify(i int) tea.Cmd {
+	if i < 0 {
+		return nil
+	}
+	command, checks := m.session.Criteria[i].Command, m.checks
+	return func() tea.Msg {
+		return criterionDoneMsg{index: i, result: checks.run(command)}
+	}
 }
 
 func (m EndModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case criterionDoneMsg:
+		result := msg.result
+		m.session.Criteria[msg.index].Result = &result
+		m.verifying = m.nextCommand(msg.index + 1)
+		m.recommend()
+		return m, m.verify(m.verifying)
+
 	case tea.KeyMsg:
+		if m.reflecting {
+			return m.updateReflection(msg)
+		}
+
+		// Number keys confirm manual criteria
+		if n, err := strconv.Atoi(msg.String()); err == nil && n >= 1 && n <= len(m.session.Criteria) {
+			if c := &m.session.Criteria[n-1]; c.Command == "" {
+				c.Confirmed = !c.Confirmed
+				m.blocked = false
+				m.recommend()
+			}
+			return m, nil
+		}
+
+		if msg.String() == "r" && m.verifying < 0 {
+			for i := range m.session.Criteria {
+				m.session.Criteria[i].Result = nil
+			}
+			m.blocked = false
+			m.verifying = m.nextCommand(0)
+			return m, m.verify(m.verifying)
+		}
+
 		switch msg.Type {
 		case tea.KeyCtrlC, tea.KeyEsc:
 			if !m.confirmed {
@@ -70,19 +269,104 @@ func (m EndModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case tea.KeyEnter:
+			if endAction(m.selected) == actionMerge && !m.canMerge() {
+				m.blocked = true
+				return m, nil
+			}
 			m.choice = endAction(m.selected)
+			if m.reflect {
+				m.reflecting = true
+				m.question = 0
+				m.reflection = session.Reflection{Action: actionNames[m.choice]}
+				m.textarea.Reset()
+				return m, m.textarea.Focus()
+			}
 			m.confirmed = true
 			return m, tea.Quit
 		}
 	}
 
+	if m.reflecting {
+		var cmd tea.Cmd
+		m.textarea, cmd = m.textarea.Update(msg)
+		return m, cmd
+	}
+
 	return m, nil
 }
 
+// updateReflection handles keys while the reflection questions are shown
+func (m EndModel) updateReflection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		// Back to the action list
+		m.reflecting = false
+		m.textarea.Blur()
+		return m, nil
+
+	case tea.KeyTab:
+		// Skip the remaining questions
+		if m.question < len(reflectionQuestions) {
+			m.saveAnswer()
+		}
+		return m.finishReflection()
+
+	case tea.KeyEnter:
+		if m.question == len(reflectionQuestions) {
+			return m.finishReflection()
+		}
+		m.saveAnswer()
+		m.question++
+		m.textarea.Reset()
+		if m.question == len(reflectionQuestions) {
+			m.textarea.Blur()
+		}
+		return m, nil
+	}
+
+	if m.question == len(reflectionQuestions) {
+		if n, err := strconv.Atoi(msg.String()); err == nil && n >= 1 && n <= len(ratingLabels) {
+			m.reflection.Rating = n
+			return m.finishReflection()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+// saveAnswer stores the textarea as the answer to the current question
+func (m *EndModel) saveAnswer() {
+	answer := strings.TrimSpace(m.textarea.Value())
+	switch m.question {
+	case 0:
+		m.reflection.Done = answer
+	case 1:
+		m.reflection.Blocked = answer
+	case 2:
+		m.reflection.Learned = answer
+	}
+}
+
+func (m EndModel) finishReflection() (tea.Model, tea.Cmd) {
+	m.reflection.Time = time.Now()
+	m.reflecting = false
+	m.confirmed = true
+	return m, tea.Quit
+}
+
 func (m EndModel) View() string {
 	if m.confirmed {
 		return m.renderConfirmation()
 	}
+	if m.reflecting {
+		return m.renderReflection()
+	}
 
 	var b strings.Builder
 
@@ -97,6 +381,16 @@ func (m EndModel) View() string {
 	b.WriteString(summaryBox)
 	b.WriteString("\n\n")
 
+	if len(m.session.Steps) > 0 {
+		b.WriteString(m.renderSteps())
+		b.WriteString("\n\n")
+	}
+
+	if len(m.session.Criteria) > 0 {
+		b.WriteString(m.renderCriteria())
+		b.WriteString("\n\n")
+	}
+
 	// Drift log if any
 	if len(m.session.Drifts) > 0 {
 		driftLog := m.renderDriftLog()
@@ -136,6 +430,14 @@ func (m EndModel) View() string {
 		}
 
 		line := fmt.Sprintf("%s%s", cursor, opt.label)
+		if endAction(i) == actionMerge && !m.canMerge() {
+			line += " (locked until the definition of done is met)"
+			if i != m.selected {
+				style = MutedStyle
+			}
+		} else if endAction(i) == m.recommended {
+			line += " (recommended)"
+		}
 		b.WriteString(style.Render(line))
 		b.WriteString("\n")
 
@@ -146,8 +448,22 @@ func (m EndModel) View() string {
 		}
 	}
 
+	if m.blocked {
+		b.WriteString("\n")
+		b.WriteString(WarningStyle.Render(fmt.Sprintf("%s Can't merge yet: the definition of done isn't met", EmojiWarning)))
+		b.WriteString("\n")
+	} else if m.advice != "" {
+		b.WriteString("\n")
+		b.WriteString(WarningStyle.Render(fmt.Sprintf("%s %s", EmojiWarning, m.advice)))
+		b.WriteString("\n")
+	}
+
 	b.WriteString("\n")
-	b.WriteString(HintStyle.Render("↑/↓ to select • Enter to confirm • Esc to cancel"))
+	hint := "↑/↓ to select • Enter to confirm • Esc to cancel"
+	if len(m.session.Criteria) > 0 {
+		hint = "↑/↓ to select • 1-9 to confirm a criterion • r to re-run checks • Enter to confirm • Esc to cancel"
+	}
+	b.WriteString(HintStyle.Render(hint))
 
 	return BaseStyle.Render(b.String())
 }
@@ -162,10 +478,101 @@ func (m EndModel) renderSummary() string {
 	b.WriteString(fmt.Sprintf("%s  Time: %s (planned: %s)\n", EmojiTime, elapsedStr, m.session.TimeBox))
 	b.WriteString(fmt.Sprintf("%s  Commits: %d\n", EmojiCommit, m.commits))
 	b.WriteString(fmt.Sprintf("%s  Drifts: %d\n", EmojiDrift, len(m.session.Drifts)))
+	if done, total := m.session.StepProgress(); total > 0 {
+		b.WriteString(fmt.Sprintf("📋  Steps: %d/%d (%d%%)\n", done, total, done*100/total))
+	}
 
 	return b.String()
 }
 
+func (m EndModel) renderSteps() string {
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("📋 Checklist:"))
+	b.WriteString("\n\n")
+
+	for i, step := range m.session.Steps {
+		if step.Done() {
+			b.WriteString(fmt.Sprintf("  %s %d. %s\n", SuccessStyle.Render("✓"), i+1, step.Title))
+		} else {
+			b.WriteString(fmt.Sprintf("  ○ %d. %s\n", i+1, step.Title))
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (m EndModel) renderCriteria() string {
+	var b strings.Builder
+
+	met, total := m.session.CriteriaMet()
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("✅ Definition of done (%d/%d):", met, total)))
+	b.WriteString("\n\n")
+
+	for i, c := range m.session.Criteria {
+		var mark string
+		switch {
+		case c.Met():
+			mark = SuccessStyle.Render("✓")
+		case c.Command != "" && i == m.verifying:
+			mark = InfoStyle.Render("⏳")
+		case c.Command != "" && c.Result != nil:
+			mark = lipgloss.NewStyle().Foreground(ColorDanger).Bold(true).Render("✗")
+		default:
+			mark = "○"
+		}
+
+		b.WriteString(fmt.Sprintf("  %s %d. %s", mark, i+1, c.Description))
+		if c.Command != "" && c.Command != c.Description {
+			b.WriteString(MutedStyle.Render(fmt.Sprintf(" ($ %s)", c.Command)))
+		}
+		if c.Result != nil {
+			b.WriteString(MutedStyle.Render(fmt.Sprintf(" %s", c.Result.Duration.Round(100*time.Millisecond))))
+		}
+		b.WriteString("\n")
+
+		// Show the end of a failing command's output
+		if c.Result != nil && !c.Result.Passed && c.Result.Output != "" {
+			lines := strings.Split(c.Result.Output, "\n")
+			for _, line := range lines[max(len(lines)-5, 0):] {
+				b.WriteString(MutedStyle.Render("      " + line))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (m EndModel) renderReflection() string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(fmt.Sprintf("%s Reflect", EmojiThink)))
+	b.WriteString("\n\n")
+	b.WriteString(MutedStyle.Render(fmt.Sprintf("Before you %s \"%s\" (%d/%d)",
+		actionNames[m.choice], m.session.Task, m.question+1, len(reflectionQuestions)+1)))
+	b.WriteString("\n\n")
+
+	if m.question < len(reflectionQuestions) {
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorInfo).Render(reflectionQuestions[m.question]))
+		b.WriteString("\n\n")
+		b.WriteString(m.textarea.View())
+		b.WriteString("\n\n")
+		b.WriteString(HintStyle.Render("Enter for the next question • Tab to skip the rest • Esc to go back"))
+		return BaseStyle.Render(b.String())
+	}
+
+	b.WriteString(lipgloss.NewStyle().Foreground(ColorInfo).Render("How focused were you?"))
+	b.WriteString("\n\n")
+	for i, label := range ratingLabels {
+		b.WriteString(fmt.Sprintf("  %s %s\n", InfoStyle.Render(fmt.Sprintf("[%d]", i+1)), label))
+	}
+	b.WriteString("\n")
+	b.WriteString(HintStyle.Render("1-5 to rate • Enter to skip • Esc to go back"))
+
+	return BaseStyle.Render(b.String())
+}
+
 func (m EndModel) renderDriftLog() string {
 	var b strings.Builder
 
@@ -201,14 +608,28 @@ func (m EndModel) renderConfirmation() string {
 }
 
 func (m EndModel) HandleAction() error {
+	// Esc leaves choice at its zero value (merge); never act on a cancel
+	if !m.confirmed {
+		return nil
+	}
+
+	// Saved with the session by Pause and Archive below
+	if r := m.Reflection(); r != nil {
+		m.session.Reflections = append(m.session.Reflections, *r)
+	}
+
 	switch m.choice {
 	case actionMerge:
 		// Merge to main and delete session
-		if err := git.MergeToMain(m.session.Task); err != nil {
+		issue := ""
+		if m.session.Issue != nil {
+			issue = m.session.Issue.Ref()
+		}
+		if err := git.MergeToMain(m.session.Task, issue); err != nil {
 			return fmt.Errorf("failed to merge: %w", err)
 		}
-		if err := m.session.Delete(); err != nil {
-			return fmt.Errorf("failed to delete session: %w", err)
+		if err := m.session.Archive("completed"); err != nil {
+			return fmt.Errorf("failed to archive session: %w", err)
 		}
 		fmt.Println("\nSession complete. Branch merged to main.")
 
@@ -224,8 +645,8 @@ func (m EndModel) HandleAction() error {
 		if err := git.DeleteBranch(); err != nil {
 			return fmt.Errorf("failed to delete branch: %w", err)
 		}
-		if err := m.session.Delete(); err != nil {
-			return fmt.Errorf("failed to delete session: %w", err)
+		if err := m.session.Archive("abandoned"); err != nil {
+			return fmt.Errorf("failed to archive session: %w", err)
 		}
 		fmt.Println("\nBranch discarded. Commits saved in reflog.")
 	}
@@ -42,6 +42,12 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		elapsedStr,
 		sess.Branch,
 	)
+	if sess.ParkedFrom != "" {
+		desc += " | " + EmojiPin + " parked"
+		if sess.Stash != "" {
+			desc += " with changes"
+		}
+	}
 
 	isSelected := index == m.Index()
 
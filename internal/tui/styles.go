@@ -63,4 +63,5 @@ var (
 	EmojiThink   = "💭"
 	EmojiTrash   = "🗑️"
 	EmojiPin     = "📌"
+	EmojiIdle    = "💤"
 )
@@ -0,0 +1,404 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/session"
+)
+
+type markKind int
+
+const (
+	markOnTrack markKind = iota
+	markDrifted
+	markDeferred
+	markCommit
+	markDrift
+	markBranch
+	markOther // Only shown in the detail pane
+)
+
+// timelineItem is one moment on the timeline
+type timelineItem struct {
+	at    time.Time
+	kind  markKind
+	label string
+}
+
+// interval is a stretch of time when focus was paused
+type interval struct {
+	start  time.Time
+	end    time.Time
+	reason string
+}
+
+// Marker symbols and styles for each row
+var (
+	markSymbols = map[markKind]string{
+		markOnTrack:  "✓",
+		markDrifted:  "✗",
+		markDeferred: "○",
+		markCommit:   "●",
+		markDrift:    "◆",
+		markBranch:   "⇄",
+		markOther:    "·",
+	}
+	markStyles = map[markKind]lipgloss.Style{
+		markOnTrack:  SuccessStyle,
+		markDrifted:  WarningStyle,
+		markDeferred: lipgloss.NewStyle().Foreground(ColorMuted),
+		markCommit:   InfoStyle,
+		markDrift:    lipgloss.NewStyle().Foreground(ColorDanger).Bold(true),
+		markBranch:   InfoStyle,
+		markOther:    lipgloss.NewStyle().Foreground(ColorMuted),
+	}
+)
+
+// labelWidth is the width of the row labels left of the timeline
+const labelWidth = 10
+
+type TimelineModel struct {
+	session  *session.Session
+	items    []timelineItem
+	paused   []interval
+	start    time.Time
+	end      time.Time
+	viewport viewport.Model
+	width    int
+	height   int
+}
+
+func NewTimelineModel(sess *session.Session, log []events.Event, commits []git.Commit, now time.Time) TimelineModel {
+	m := TimelineModel{
+		session:  sess,
+		start:    sess.StartTime,
+		end:      now,
+		viewport: viewport.New(80, 10),
+	}
+
+	for _, p := range sess.Pauses {
+		end := now
+		if p.End != nil {
+			end = *p.End
+		}
+		m.paused = append(m.paused, interval{start: p.Start, end: end, reason: p.Reason})
+	}
+
+	for _, d := range sess.Drifts {
+		label := "Drift: " + d.Description
+		if d.Category != "" {
+			label = fmt.Sprintf("Drift [%s]: %s", d.Category.Label(), d.Description)
+		}
+		if d.Reason != "" {
+			label += fmt.Sprintf(" (%s)", d.Reason)
+		}
+		m.items = append(m.items, timelineItem{at: d.Timestamp, kind: markDrift, label: label})
+	}
+
+	for _, c := range commits {
+		m.items = append(m.items, timelineItem{at: c.Time, kind: markCommit, label: c.Hash + " " + c.Subject})
+	}
+
+	m.addEvents(log, now)
+
+	sort.SliceStable(m.items, func(i, j int) bool { return m.items[i].at.Before(m.items[j].at) })
+	sort.SliceStable(m.paused, func(i, j int) bool { return m.paused[i].start.Before(m.paused[j].start) })
+
+	m.viewport.SetContent(m.renderDetails())
+	return m
+}
+
+// addEvents turns event log entries into timeline items. Drifts come from
+// the session itself, so they're skipped here.
+func (m *TimelineModel) addEvents(log []events.Event, now time.Time) {
+	var pausedAt *time.Time
+
+	// Sessions record their paused spans; older ones only have the events
+	recorded := slices.ContainsFunc(m.session.Pauses, func(p session.Pause) bool { return p.Reason == "paused" })
+
+	for _, e := range log {
+		item := timelineItem{at: e.Time, kind: markOther}
+
+		switch e.Type {
+		case events.CheckAnswered:
+			switch e.Data["answer"] {
+			case "yes":
+				item.kind, item.label = markOnTrack, "Check-in: on track"
+			case "no":
+				item.kind, item.label = markDrifted, "Check-in: drifted"
+			case "defer":
+				item.kind, item.label = markDeferred, "Check-in: deferred"
+			case "switch":
+				item.kind, item.label = markOnTrack, "Check-in: switched back to the focus branch"
+			default:
+				continue
+			}
+			if e.Data["source"] == "notification" {
+				item.label += " (from notification)"
+			}
+		case events.BranchLeft:
+			item.kind, item.label = markBranch, fmt.Sprintf("Left focus branch for %s", e.Data["branch"])
+		case events.BranchReturned:
+			item.kind, item.label = markBranch, fmt.Sprintf("Back from %s after %s", e.Data["branch"], e.Data["duration"])
+		case events.DriftParked:
+			item.label = "Drift parked on " + e.Data["branch"]
+		case events.IdeaCaptured:
+			item.label = fmt.Sprintf("Saved for later: #%s %s", e.Data["id"], e.Data["title"])
+		case events.StepDone:
+			item.label = fmt.Sprintf("Step %s done: %s", e.Data["step"], e.Data["title"])
+		case events.MeetingSoon:
+			item.label = fmt.Sprintf("Heads-up: %s at %s", e.Data["summary"], e.Data["start"])
+		case events.MeetingStarted:
+			item.label = "Paused for meeting: " + e.Data["summary"]
+		case events.MeetingEnded:
+			item.label = "Back from meeting after " + e.Data["duration"]
+		case events.NoteAdded:
+			item.label = "Note: " + e.Data["text"]
+		case events.SessionExtended:
+			item.label = "Timebox extended to " + e.Data["timebox"]
+		case events.Snoozed:
+			item.label = "Reminders snoozed"
+		case events.ReminderSent:
+			item.label = fmt.Sprintf("Reminder sent (%s)", e.Data["kind"])
+		case events.PomodoroPhase:
+			item.label = fmt.Sprintf("Pomodoro: %s (%s done)", strings.ReplaceAll(e.Data["phase"], "_", " "), e.Data["completed"])
+		case events.SessionPaused:
+			at := e.Time
+			pausedAt = &at
+			item.label = "Session paused"
+		case events.SessionResumed:
+			if pausedAt != nil && !recorded {
+				m.paused = append(m.paused, interval{start: *pausedAt, end: e.Time, reason: "paused"})
+				pausedAt = nil
+			}
+			item.label = "Session resumed"
+		default:
+			continue
+		}
+
+		m.items = append(m.items, item)
+	}
+
+	if pausedAt != nil && !recorded {
+		m.paused = append(m.paused, interval{start: *pausedAt, end: now, reason: "paused"})
+	}
+}
+
+func (m TimelineModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m TimelineModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.viewport.Width = msg.Width - 4
+		m.viewport.Height = max(msg.Height-23, 5)
+		m.viewport.SetContent(m.renderDetails())
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m TimelineModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render("📊 Session Timeline"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s  %s\n", EmojiGoal, m.session.Task))
+	b.WriteString(MutedStyle.Render(fmt.Sprintf("%s → %s • focused %s of %s",
+		m.start.Format("15:04"), m.end.Format("15:04"),
+		formatDuration(m.session.FocusedTime(m.end)), formatDuration(m.end.Sub(m.start)))))
+	b.WriteString("\n\n")
+
+	b.WriteString(m.renderTimeline(m.timelineWidth()))
+	b.WriteString("\n")
+	b.WriteString(m.renderLegend())
+	b.WriteString("\n\n")
+
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Details"))
+	b.WriteString("\n")
+	b.WriteString(m.viewport.View())
+	b.WriteString("\n")
+	b.WriteString(HintStyle.Render("↑/↓ to scroll • q to quit"))
+
+	return BaseStyle.Render(b.String())
+}
+
+// timelineWidth is the number of columns available for the bar
+func (m TimelineModel) timelineWidth() int {
+	width := m.width - 4 - labelWidth
+	if m.width == 0 {
+		width = 60
+	}
+	return max(width, 20)
+}
+
+// column maps a time onto one of width columns
+func (m TimelineModel) column(t time.Time, width int) int {
+	total := m.end.Sub(m.start)
+	if total <= 0 {
+		return 0
+	}
+	col := int(float64(t.Sub(m.start)) / float64(total) * float64(width))
+	return min(max(col, 0), width-1)
+}
+
+// isPaused reports whether t falls inside a paused interval
+func (m TimelineModel) isPaused(t time.Time) bool {
+	for _, p := range m.paused {
+		if !t.Before(p.start) && t.Before(p.end) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m TimelineModel) renderTimeline(width int) string {
+	var b strings.Builder
+	label := lipgloss.NewStyle().Width(labelWidth).Foreground(ColorMuted)
+
+	// Focus bar: one cell per slice of the session
+	focused := lipgloss.NewStyle().Foreground(ColorSuccess)
+	paused := lipgloss.NewStyle().Foreground(ColorMuted)
+	slot := m.end.Sub(m.start) / time.Duration(width)
+
+	b.WriteString(label.Render("Focus"))
+	for col := 0; col < width; col++ {
+		mid := m.start.Add(slot*time.Duration(col) + slot/2)
+		if m.isPaused(mid) {
+			b.WriteString(paused.Render("░"))
+		} else {
+			b.WriteString(focused.Render("█"))
+		}
+	}
+	b.WriteString("\n")
+
+	rows := []struct {
+		name  string
+		kinds []markKind
+	}{
+		{"Check-ins", []markKind{markOnTrack, markDrifted, markDeferred}},
+		{"Commits", []markKind{markCommit}},
+		{"Drifts", []markKind{markDrift, markBranch}},
+	}
+
+	for _, row := range rows {
+		b.WriteString(label.Render(row.name))
+		b.WriteString(m.renderMarkers(width, row.kinds))
+		b.WriteString("\n")
+	}
+
+	// Time axis with start, middle and end labels
+	axis := []rune(strings.Repeat(" ", width))
+	place := func(col int, text string) {
+		col = min(max(col, 0), width-len(text))
+		copy(axis[col:], []rune(text))
+	}
+	place(0, m.start.Format("15:04"))
+	place(width/2-2, m.start.Add(m.end.Sub(m.start)/2).Format("15:04"))
+	place(width-5, m.end.Format("15:04"))
+	b.WriteString(label.Render(""))
+	b.WriteString(MutedStyle.Render(string(axis)))
+
+	return b.String()
+}
+
+// renderMarkers draws one row of markers. When several land in the same
+// column, the later one wins.
+func (m TimelineModel) renderMarkers(width int, kinds []markKind) string {
+	cells := make([]string, width)
+	for i := range cells {
+		cells[i] = " "
+	}
+
+	for _, item := range m.items {
+		for _, kind := range kinds {
+			if item.kind == kind {
+				cells[m.column(item.at, width)] = markStyles[kind].Render(markSymbols[kind])
+			}
+		}
+	}
+
+	return strings.Join(cells, "")
+}
+
+func (m TimelineModel) renderLegend() string {
+	indent := strings.Repeat(" ", labelWidth)
+
+	bar := []string{
+		lipgloss.NewStyle().Foreground(ColorSuccess).Render("█") + " focused",
+		lipgloss.NewStyle().Foreground(ColorMuted).Render("░") + " paused",
+	}
+
+	var marks []string
+	names := []string{"on track", "drifted", "deferred", "commit", "drift", "branch switch"}
+	for kind, name := range names {
+		marks = append(marks, markStyles[markKind(kind)].Render(markSymbols[markKind(kind)])+" "+name)
+	}
+
+	return indent + strings.Join(bar, "  ") + "\n" + indent + strings.Join(marks, "  ")
+}
+
+// renderDetails lists every item and pause in order for the viewport
+func (m TimelineModel) renderDetails() string {
+	type line struct {
+		at   time.Time
+		text string
+	}
+
+	var lines []line
+	for _, item := range m.items {
+		symbol := markStyles[item.kind].Render(markSymbols[item.kind])
+		lines = append(lines, line{item.at, fmt.Sprintf("%s  %s", symbol, item.label)})
+	}
+	for _, p := range m.paused {
+		text := fmt.Sprintf("%s  %s %s–%s (%s)",
+			lipgloss.NewStyle().Foreground(ColorMuted).Render("░"),
+			pauseLabels[p.reason], p.start.Format("15:04"), p.end.Format("15:04"),
+			formatDuration(p.end.Sub(p.start)))
+		lines = append(lines, line{p.start, text})
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].at.Before(lines[j].at) })
+
+	if len(lines) == 0 {
+		return MutedStyle.Render("Nothing recorded yet.")
+	}
+
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(MutedStyle.Render(l.at.Format("15:04")))
+		b.WriteString("  ")
+		b.WriteString(l.text)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// pauseLabels describe why focus was paused
+var pauseLabels = map[string]string{
+	"idle":    "Idle",
+	"break":   "Break",
+	"meeting": "Meeting",
+	"paused":  "Session paused",
+}
@@ -0,0 +1,138 @@
+package watcher
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/n3sty/focus/internal/activity"
+	"github.com/n3sty/focus/internal/calendar"
+	"github.com/n3sty/focus/internal/clock"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/git"
+	"github.com/n3sty/focus/internal/notify"
+	"github.com/n3sty/focus/internal/session"
+)
+
+// Sessions loads and saves the active session
+type Sessions interface {
+	Load() (*session.Session, error)
+	Save(sess *session.Session) error
+}
+
+// Deps are everything the watcher touches outside its own state. Watch
+// wires up the real implementations; tests can pass fakes to drive the
+// loop tick by tick.
+type Deps struct {
+	Clock     clock.Clock
+	Ticks     <-chan time.Time // One session check per tick
+	Signals   <-chan os.Signal // Any signal stops the watcher
+	Sessions  Sessions
+	Notifier  notify.Notifier
+	Actions   <-chan string          // Keys of clicked notification buttons (nil if unsupported)
+	Publisher notify.Publisher       // Lifecycle events for teammates
+	Queued    func() int             // Webhook deliveries waiting in the queue file
+	Branch    func() (string, error) // Current git branch
+	Activity  func() time.Time       // Most recent sign of activity
+
+	// Meetings returns calendar events overlapping from-to (nil without a
+	// calendar)
+	Meetings func(from, to time.Time) []calendar.Event
+
+	// Record appends to the session's event log
+	Record func(e events.Event) error
+
+	// RunHook runs user hooks for a lifecycle event
+	RunHook func(event string, sess *session.Session, env map[string]string) error
+}
+
+// fileSessions reads and writes sessions in .focus/
+type fileSessions struct{}
+
+func (fileSessions) Load() (*session.Session, error)  { return session.Load() }
+func (fileSessions) Save(sess *session.Session) error { return sess.Save() }
+
+// Webhook deliveries run in the background so a dead endpoint can't
+// delay reminders
+const (
+	publishQueue   = 32
+	publishTimeout = 10 * time.Second // How long shutdown waits for pending events
+)
+
+// realDeps returns dependencies backed by the system. The returned stop
+// function releases the ticker, signal handler, action listener and
+// webhook publisher.
+func realDeps(cfg Config) (Deps, func()) {
+	ticker := time.NewTicker(cfg.CheckInterval)
+
+	// Buttons are a nice-to-have; reminders still work without them
+	actions, stopActions, err := notify.ListenActions(cfg.Notifier)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not listen for notification actions: %v\n", err)
+		stopActions = func() {}
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
+
+	publisher := notify.NewAsync(cfg.Webhooks, publishQueue)
+
+	deps := Deps{
+		Clock:     clock.Real{},
+		Ticks:     ticker.C,
+		Signals:   sigChan,
+		Sessions:  fileSessions{},
+		Notifier:  cfg.Notifier,
+		Actions:   actions,
+		Publisher: publisher,
+		Queued:    notify.Queued,
+		Record:    events.Append,
+		RunHook:   cfg.Hooks.Run,
+		Branch:    git.GetCurrentBranch,
+		Activity:  func() time.Time { return activity.Last(".") },
+	}
+	if cfg.Calendar.Path != "" {
+		deps.Meetings = calendarMeetings(cfg.Calendar.Path)
+	}
+
+	stop := func() {
+		ticker.Stop()
+		signal.Stop(sigChan)
+		stopActions()
+		publisher.Close(publishTimeout)
+	}
+
+	return deps, stop
+}
+
+const (
+	calendarReload = 5 * time.Minute // How often the calendar export is read again
+	calendarWindow = 24 * time.Hour  // How far around a query meetings are loaded
+)
+
+// calendarMeetings reads meetings from a calendar export, picking up
+// changes every few minutes. A broken file keeps the last good copy.
+func calendarMeetings(path string) func(from, to time.Time) []calendar.Event {
+	var (
+		cached               []calendar.Event
+		loadedAt             time.Time
+		loadedFrom, loadedTo time.Time
+	)
+
+	return func(from, to time.Time) []calendar.Event {
+		outside := from.Before(loadedFrom) || to.After(loadedTo)
+		if outside || time.Since(loadedAt) >= calendarReload {
+			loadedAt = time.Now()
+			loadedFrom, loadedTo = from.Add(-calendarWindow), to.Add(calendarWindow)
+			evs, err := calendar.Load(path, loadedFrom, loadedTo)
+			if err != nil {
+				fmt.Printf("⚠️  Warning: Could not read calendar: %v\n", err)
+			} else {
+				cached = evs
+			}
+		}
+		return calendar.Between(cached, from, to)
+	}
+}
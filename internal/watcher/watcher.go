@@ -2,28 +2,154 @@ package watcher
 
 import (
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
+	"strconv"
 	"time"
 
+	"github.com/n3sty/focus/internal/clock"
+	"github.com/n3sty/focus/internal/config"
 	"github.com/n3sty/focus/internal/daemon"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/hooks"
 	"github.com/n3sty/focus/internal/notify"
+	"github.com/n3sty/focus/internal/pomodoro"
+	"github.com/n3sty/focus/internal/reminder"
 	"github.com/n3sty/focus/internal/session"
 )
 
 // Config holds watcher configuration
 type Config struct {
-	CheckInterval    time.Duration // How often to check session state
-	ReminderInterval time.Duration // How often to send "focus check" reminders
+	CheckInterval time.Duration   // How often to check session state
+	IdleThreshold time.Duration   // Pause focused time after this long without activity (0 disables)
+	Reminders     reminder.Policy // When to send check, warning and expiry reminders
+	Notifier      notify.Notifier // Where reminders go
+	Webhooks      notify.Webhooks // Where lifecycle events go
+	Hooks         hooks.Runner    // User scripts for on-expire and on-drift
+	Calendar      Calendar        // Meetings to warn about and pause for
+}
+
+// Calendar configures meeting heads-ups
+type Calendar struct {
+	Path      string        // .ics file or directory; empty disables meeting checks
+	HeadsUp   time.Duration // Notify this long before a meeting starts (0 disables)
+	AutoPause bool          // Pause focused time while a meeting runs
 }
 
 // DefaultConfig returns sensible defaults
 func DefaultConfig() Config {
 	return Config{
-		CheckInterval:    30 * time.Second, // Check every 30s
-		ReminderInterval: 25 * time.Minute, // Reminder every 25 min (Pomodoro)
+		CheckInterval: 30 * time.Second, // Check every 30s
+		IdleThreshold: 10 * time.Minute, // Idle after 10 min without activity
+		Reminders:     reminder.DefaultPolicy(),
+		Notifier:      notify.Default(),
+		Calendar:      Calendar{HeadsUp: 5 * time.Minute},
+	}
+}
+
+// LoadConfig returns the defaults overridden by .focus/config.json
+func LoadConfig() (Config, error) {
+	cfg := DefaultConfig()
+
+	settings, err := config.Load()
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.IdleThreshold, err = config.ParseDuration(settings.Watcher.IdleThreshold, cfg.IdleThreshold)
+	if err != nil {
+		return cfg, fmt.Errorf("watcher.idle_threshold: %w", err)
+	}
+
+	cfg.Reminders, err = reminder.PolicyFrom(settings.Reminders)
+	if err != nil {
+		return cfg, err
+	}
+
+	if len(settings.Notifiers) > 0 {
+		cfg.Notifier, err = notify.FromConfig(settings.Notifiers)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	cfg.Webhooks, err = notify.WebhooksFromConfig(settings.Webhooks)
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.Hooks, err = hooks.FromConfig(settings.Hooks)
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.Calendar.Path = settings.Calendar.Path
+	cfg.Calendar.AutoPause = settings.Calendar.AutoPause
+	cfg.Calendar.HeadsUp, err = config.ParseDuration(settings.Calendar.HeadsUp, cfg.Calendar.HeadsUp)
+	if err != nil {
+		return cfg, fmt.Errorf("calendar.heads_up: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Durations used by notification buttons
+const (
+	extendBy  = 15 * time.Minute
+	snoozeFor = 10 * time.Minute
+)
+
+// reminderKinds names reminder kinds in the event log
+var reminderKinds = map[reminder.Kind]string{
+	reminder.KindCheck:   "check",
+	reminder.KindWarning: "warning",
+	reminder.KindExpired: "expired",
+	reminder.KindNag:     "nag",
+}
+
+// flushInterval is how often queued webhook events are retried
+const flushInterval = 5 * time.Minute
+
+// Watcher checks the active session on every tick and sends reminders
+type Watcher struct {
+	cfg  Config
+	deps Deps
+
+	// Tracking state, reset whenever a different session becomes active
+	sessionID string
+	reminders *reminder.Engine
+	lastFlush time.Time
+	queued    int             // Queue length at the last tick
+	warned    map[string]bool // Meetings already announced, by calendar.Event.Key
+}
+
+// New creates a watcher with the given dependencies. Apart from Sessions,
+// missing dependencies default to the real clock or to doing nothing.
+func New(cfg Config, deps Deps) *Watcher {
+	if deps.Clock == nil {
+		deps.Clock = clock.Real{}
+	}
+	if deps.Notifier == nil {
+		deps.Notifier = notify.Multi{}
+	}
+	if deps.Publisher == nil {
+		deps.Publisher = notify.Webhooks{}
+	}
+	if deps.Queued == nil {
+		deps.Queued = func() int { return 0 }
+	}
+	if deps.Branch == nil {
+		deps.Branch = func() (string, error) { return "", nil }
+	}
+	if deps.Activity == nil {
+		deps.Activity = func() time.Time { return time.Time{} }
+	}
+	if deps.Record == nil {
+		deps.Record = func(events.Event) error { return nil }
+	}
+	if deps.RunHook == nil {
+		deps.RunHook = func(string, *session.Session, map[string]string) error { return nil }
 	}
+
+	return &Watcher{cfg: cfg, deps: deps}
 }
 
 // Watch starts watching the focus session
@@ -34,61 +160,448 @@ func Watch(cfg Config) error {
 	}
 	defer daemon.CleanPID()
 
-	// Setup signal handling for graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
+	deps, stop := realDeps(cfg)
+	defer stop()
 
-	// Tracking state
-	lastReminder := time.Now()
-	timeboxExpiredNotified := false
+	fmt.Println("🔍 Focus watcher started (running in background)")
 
-	ticker := time.NewTicker(cfg.CheckInterval)
-	defer ticker.Stop()
+	return New(cfg, deps).Run()
+}
 
-	fmt.Println("🔍 Focus watcher started (running in background)")
+// Run processes ticks until a signal arrives, the tick channel closes, or
+// the active session disappears
+func (w *Watcher) Run() error {
+	// Send what 'focus end' queued on its way out
+	defer w.flush(w.deps.Clock.Now())
 
 	for {
 		select {
-		case <-ticker.C:
-			// Load session
-			sess, err := session.Load()
-			if err != nil {
-				// Session doesn't exist, stop watching
+		case _, ok := <-w.deps.Ticks:
+			if !ok || !w.Tick() {
 				return nil
 			}
 
-			now := time.Now()
-			elapsed := now.Sub(sess.StartTime)
+		case key, ok := <-w.deps.Actions:
+			if !ok {
+				// Listener went away; keep watching without buttons
+				w.deps.Actions = nil
+				continue
+			}
+			w.HandleAction(key)
+
+		case <-w.deps.Signals:
+			fmt.Println("🛑 Focus watcher stopped")
+			return nil
+		}
+	}
+}
+
+// Tick checks the session once. Returns false when there is no active
+// session any more and the watcher should stop.
+func (w *Watcher) Tick() bool {
+	w.flush(w.deps.Clock.Now())
+
+	sess, err := w.deps.Sessions.Load()
+	if err != nil {
+		// Session doesn't exist, stop watching
+		return false
+	}
+
+	// A paused session stays behind the active pointer until another one
+	// starts or it's resumed. Sit it out, and start fresh on resume.
+	if sess.Status != "active" {
+		w.sessionID = ""
+		return true
+	}
+
+	now := w.deps.Clock.Now()
+
+	if sess.ID != w.sessionID {
+		w.sessionID = sess.ID
+		w.reminders = reminder.NewEngine(w.cfg.Reminders, w.deps.Clock)
+		w.warned = map[string]bool{}
+	}
+
+	// Warn when work moves off the focus branch
+	w.checkBranch(sess, now)
+
+	// Warn about upcoming meetings and sit them out
+	if w.checkMeetings(sess, now) {
+		return true
+	}
+
+	// Stop the clock while nobody is working
+	if w.checkIdle(sess, now) {
+		return true
+	}
+
+	// Drive pomodoro work/break cycles; no reminders during breaks
+	if sess.Pomodoro != nil {
+		w.checkPomodoro(sess, now)
+		if sess.Pomodoro.Phase.IsBreak() {
+			return true
+		}
+	}
+
+	// Parse timebox duration
+	timeboxDuration, err := parseTimebox(sess.TimeBox)
+	if err != nil {
+		return true
+	}
+
+	in := reminder.Input{
+		Elapsed: sess.FocusedTime(now),
+		Timebox: timeboxDuration,
+	}
+	if sess.SnoozedUntil != nil {
+		in.SnoozedUntil = *sess.SnoozedUntil
+	}
+	if last := sess.LastCheckIn(); last != nil {
+		in.LastCheckIn = last.Time
+	}
+
+	if r := w.reminders.Next(in); r != nil {
+		// Pomodoro sessions get a check prompt at the end of each work interval instead
+		if r.Kind == reminder.KindCheck && sess.Pomodoro != nil {
+			return true
+		}
+		w.sendReminder(sess, r)
+		if r.Kind == reminder.KindExpired {
+			w.deps.RunHook(hooks.OnExpire, sess, nil)
+		}
+	}
+
+	return true
+}
+
+// HandleAction applies a button clicked on a reminder notification to the
+// active session, so the user doesn't have to open a terminal
+func (w *Watcher) HandleAction(key string) {
+	sess, err := w.deps.Sessions.Load()
+	if err != nil || sess.Status != "active" {
+		return
+	}
+
+	now := w.deps.Clock.Now()
+
+	switch key {
+	case notify.ActionOnTrack:
+		// The check-in restarts the reminder interval
+		sess.AddCheckIn("yes", "", "notification", now)
+		w.record(sess, events.CheckAnswered, map[string]string{"answer": "yes", "source": "notification"})
+	case notify.ActionDrift:
+		sess.AddDrift("Drifted (reported from notification)", "", now)
+		sess.AddCheckIn("no", "", "notification", now)
+		w.record(sess, events.CheckAnswered, map[string]string{"answer": "no", "source": "notification"})
+		w.recordDrift(sess)
+		defer w.driftHook(sess)
+	case notify.ActionExtend:
+		if err := sess.Extend(extendBy); err != nil {
+			return
+		}
+		w.record(sess, events.SessionExtended, map[string]string{"timebox": sess.TimeBox, "source": "notification"})
+		w.notify("⏱️ Timebox Extended", fmt.Sprintf("New timebox: %s", sess.TimeBox), notify.UrgencyLow)
+	case notify.ActionSnooze:
+		sess.Snooze(snoozeFor, now)
+		w.record(sess, events.Snoozed, map[string]string{"until": sess.SnoozedUntil.Format(time.RFC3339), "source": "notification"})
+	default:
+		return
+	}
+
+	w.save(sess)
+
+	if key == notify.ActionExtend {
+		w.deps.Publisher.Publish(notify.NewEvent(notify.EventExtended, sess))
+	}
+}
+
+// save writes the session back; a failed write is logged and retried
+// with the next change
+func (w *Watcher) save(sess *session.Session) {
+	if err := w.deps.Sessions.Save(sess); err != nil {
+		fmt.Printf("⚠️  Warning: Could not save session: %v\n", err)
+	}
+}
+
+// record appends an event to the session's event log
+func (w *Watcher) record(sess *session.Session, t events.Type, data map[string]string) {
+	w.deps.Record(events.Event{
+		Time:      w.deps.Clock.Now(),
+		Type:      t,
+		SessionID: sess.ID,
+		Data:      data,
+	})
+}
+
+// recordDrift logs the most recently added drift
+func (w *Watcher) recordDrift(sess *session.Session) {
+	drift := sess.Drifts[len(sess.Drifts)-1]
+	w.record(sess, events.DriftLogged, drift.Fields())
+}
+
+// driftHook runs on-drift hooks for the most recently logged drift
+func (w *Watcher) driftHook(sess *session.Session) {
+	drift := sess.Drifts[len(sess.Drifts)-1]
+	w.deps.RunHook(hooks.OnDrift, sess, map[string]string{
+		"FOCUS_DRIFT":          drift.Description,
+		"FOCUS_DRIFT_REASON":   drift.Reason,
+		"FOCUS_DRIFT_CATEGORY": string(drift.Category),
+		"FOCUS_DRIFT_SEVERITY": string(drift.Severity),
+	})
+}
+
+// sendReminder turns a reminder into a notification
+func (w *Watcher) sendReminder(sess *session.Session, r *reminder.Reminder) {
+	var title, message string
+
+	switch r.Kind {
+	case reminder.KindCheck:
+		title = "🎯 Focus Check"
+		message = fmt.Sprintf("Still working on: %s? Run 'focus check'", sess.Task)
+	case reminder.KindWarning:
+		title = "⏳ Timebox Ending Soon"
+		message = fmt.Sprintf("%s left for '%s'. Time to wrap up", formatMinutes(r.Left), sess.Task)
+	case reminder.KindExpired:
+		title = "⏱️ Focus Timebox Expired!"
+		message = fmt.Sprintf("Your %s timebox for '%s' has ended. Run 'focus check' or 'focus end'", sess.TimeBox, sess.Task)
+	case reminder.KindNag:
+		title = "⏰ Over Timebox"
+		if r.Level >= 3 {
+			title = "🚨 Way Over Timebox"
+		}
+		message = fmt.Sprintf("%s over on '%s'. Run 'focus end' or 'focus snooze'", formatMinutes(r.Over), sess.Task)
+	}
+
+	w.record(sess, events.ReminderSent, map[string]string{
+		"kind":  reminderKinds[r.Kind],
+		"level": strconv.Itoa(r.Level),
+	})
+
+	// Remember the prompt so the answer's latency can be measured
+	if r.Kind == reminder.KindCheck {
+		sess.Prompt(w.deps.Clock.Now())
+		w.save(sess)
+	}
+
+	urgency := notify.UrgencyNormal
+	if r.Urgent {
+		urgency = notify.UrgencyCritical
+	}
+	w.deps.Notifier.Notify(notify.Notification{
+		Title:   title,
+		Message: message,
+		Urgency: urgency,
+		Actions: notify.ReminderActions,
+	})
+}
+
+// notify sends a notification; delivery failures are not fatal
+func (w *Watcher) notify(title, message string, urgency notify.Urgency) {
+	w.deps.Notifier.Notify(notify.Notification{
+		Title:   title,
+		Message: message,
+		Urgency: urgency,
+	})
+}
+
+// flush hands queued webhook events to the publisher as soon as a
+// command queues new ones, and retries the ones that failed while offline
+// every flushInterval
+func (w *Watcher) flush(now time.Time) {
+	queued := w.deps.Queued()
+	if queued > w.queued || now.Sub(w.lastFlush) >= flushInterval {
+		w.lastFlush = now
+		w.deps.Publisher.Flush()
+	}
+	w.queued = queued
+}
+
+// checkBranch compares HEAD with the focus branch and notifies on divergence
+func (w *Watcher) checkBranch(sess *session.Session, now time.Time) {
+	current, err := w.deps.Branch()
+	if err != nil {
+		return
+	}
+
+	off := sess.OffBranch
+
+	switch sess.TrackBranch(current, now) {
+	case session.BranchLeft:
+		w.record(sess, events.BranchLeft, map[string]string{"branch": current})
+		w.notify(
+			"🔀 Left Focus Branch",
+			fmt.Sprintf("You're on '%s' but your goal is '%s'. Run 'focus check' to switch back", current, sess.Task),
+			notify.UrgencyCritical,
+		)
+	case session.BranchReturned:
+		w.record(sess, events.BranchReturned, map[string]string{
+			"branch":   off.Branch,
+			"duration": now.Sub(off.Since).Round(time.Second).String(),
+		})
+		w.recordDrift(sess)
+		w.notify(
+			"🎯 Back on Track",
+			fmt.Sprintf("Back on %s. Time away was logged as a drift", sess.Branch),
+			notify.UrgencyNormal,
+		)
+		defer w.driftHook(sess)
+	default:
+		return
+	}
+
+	w.save(sess)
+}
+
+// checkMeetings sends a heads-up before each meeting and, with
+// AutoPause, stops focused time while one runs. Returns true during a
+// meeting pause.
+func (w *Watcher) checkMeetings(sess *session.Session, now time.Time) bool {
+	if w.deps.Meetings == nil {
+		return false
+	}
 
-			// Parse timebox duration
-			timeboxDuration, err := parseTimebox(sess.TimeBox)
-			if err != nil {
+	if w.cfg.Calendar.HeadsUp > 0 {
+		for _, m := range w.deps.Meetings(now, now.Add(w.cfg.Calendar.HeadsUp)) {
+			if m.Start.Before(now) || w.warned[m.Key()] {
 				continue
 			}
+			w.warned[m.Key()] = true
 
-			// Check if timebox expired
-			if elapsed >= timeboxDuration && !timeboxExpiredNotified {
-				notify.SendUrgent(
-					"⏱️ Focus Timebox Expired!",
-					fmt.Sprintf("Your %s timebox for '%s' has ended. Run 'focus check' or 'focus end'", sess.TimeBox, sess.Task),
-				)
-				timeboxExpiredNotified = true
+			message := fmt.Sprintf("'%s' starts at %s.", m.Summary, m.Start.Format("15:04"))
+			if w.cfg.Calendar.AutoPause {
+				message += " Focus will pause while it runs"
+			} else {
+				message += " Commit and note where you are, or run 'focus end' to pause"
 			}
+			w.record(sess, events.MeetingSoon, map[string]string{"summary": m.Summary, "start": m.Start.Format("15:04")})
+			w.notify(fmt.Sprintf("📅 Meeting in %s", formatMinutes(m.Start.Sub(now).Round(time.Minute))), message, notify.UrgencyCritical)
+		}
+	}
 
-			// Send periodic reminders
-			if now.Sub(lastReminder) >= cfg.ReminderInterval && !timeboxExpiredNotified {
-				notify.Send(
-					"🎯 Focus Check",
-					fmt.Sprintf("Still working on: %s? Run 'focus check'", sess.Task),
-				)
-				lastReminder = now
+	if !w.cfg.Calendar.AutoPause {
+		return false
+	}
+
+	running := w.deps.Meetings(now, now.Add(time.Second))
+	open := sess.OpenPause()
+	inMeeting := open != nil && open.Reason == "meeting"
+
+	switch {
+	case open == nil && len(running) > 0:
+		// Count from when the meeting began, not the first tick after it
+		start := running[0].Start
+		if start.Before(sess.StartTime) {
+			start = sess.StartTime
+		}
+		for _, p := range sess.Pauses {
+			// Don't overlap an idle stretch that just ended
+			if p.End != nil && p.End.After(start) {
+				start = *p.End
 			}
+		}
+		sess.StartMeeting(start)
+		w.save(sess)
+		w.record(sess, events.MeetingStarted, map[string]string{"summary": running[0].Summary})
+		w.notify("📅 Focus Paused", fmt.Sprintf("Paused for '%s'. Focus resumes when it ends", running[0].Summary), notify.UrgencyLow)
+		return true
 
-		case <-sigChan:
-			fmt.Println("🛑 Focus watcher stopped")
-			return nil
+	case inMeeting && len(running) == 0:
+		sess.EndMeeting(now)
+		w.save(sess)
+		w.record(sess, events.MeetingEnded, map[string]string{"duration": now.Sub(open.Start).Round(time.Second).String()})
+		w.notify("🎯 Back to Focus", fmt.Sprintf("Meeting's over. Back to: %s", sess.Task), notify.UrgencyNormal)
+		return false
+	}
+
+	return inMeeting
+}
+
+// checkIdle pauses focused time after IdleThreshold without activity
+// and resumes it once activity is seen again. Returns true while idle.
+func (w *Watcher) checkIdle(sess *session.Session, now time.Time) bool {
+	if w.cfg.IdleThreshold <= 0 {
+		return false
+	}
+
+	last := w.deps.Activity()
+	if last.IsZero() {
+		return false
+	}
+	if last.Before(sess.StartTime) {
+		last = sess.StartTime
+	}
+	for _, p := range sess.Pauses {
+		// Don't overlap a break or meeting that already ended
+		if p.End != nil && p.End.After(last) {
+			last = *p.End
 		}
 	}
+	open := sess.OpenPause()
+	if open != nil && open.Reason != "idle" {
+		// Pomodoro breaks are handled by checkPomodoro
+		return false
+	}
+
+	switch {
+	case open == nil && now.Sub(last) >= w.cfg.IdleThreshold:
+		sess.StartIdle(last)
+		w.save(sess)
+		w.record(sess, events.IdleStarted, map[string]string{"since": last.Format(time.RFC3339)})
+		return true
+
+	case open != nil && last.After(open.Start):
+		idle := last.Sub(open.Start).Round(time.Minute)
+		sess.EndIdle(last)
+		w.save(sess)
+		w.record(sess, events.IdleEnded, map[string]string{"duration": last.Sub(open.Start).Round(time.Second).String()})
+		w.notify(
+			"👋 Welcome Back",
+			fmt.Sprintf("You were idle for %s. Run 'focus check' to say if it was a break", idle),
+			notify.UrgencyNormal,
+		)
+		return false
+	}
+
+	return open != nil
+}
+
+// checkPomodoro advances the pomodoro cycle and announces phase changes
+func (w *Watcher) checkPomodoro(sess *session.Session, now time.Time) {
+	pomo := sess.Pomodoro
+	if !pomo.Advance(now) {
+		return
+	}
+
+	w.record(sess, events.PomodoroPhase, map[string]string{
+		"phase":     string(pomo.Phase),
+		"completed": strconv.Itoa(pomo.Completed),
+	})
+
+	switch pomo.Phase {
+	case pomodoro.PhaseWork:
+		sess.EndBreak(now)
+		w.notify(
+			"🍅 Back to Work",
+			fmt.Sprintf("Break's over. %s on: %s", formatMinutes(pomo.Plan.Work), sess.Task),
+			notify.UrgencyNormal,
+		)
+	default:
+		sess.StartBreak(now)
+		sess.Prompt(now)
+		w.notify(
+			"☕ Break Time",
+			fmt.Sprintf("Pomodoro #%d done! Take %s. Still on '%s'? Run 'focus check'",
+				pomo.Completed, formatMinutes(pomo.PhaseLength()), sess.Task),
+			notify.UrgencyCritical,
+		)
+	}
+
+	w.save(sess)
+}
+
+// formatMinutes renders a duration like "25m"
+func formatMinutes(d time.Duration) string {
+	return fmt.Sprintf("%dm", int(d.Minutes()))
 }
 
 // parseTimebox converts "3h", "90m", "2h30m" to time.Duration
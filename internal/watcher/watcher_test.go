@@ -0,0 +1,395 @@
+package watcher
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/n3sty/focus/internal/calendar"
+	"github.com/n3sty/focus/internal/clock"
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/hooks"
+	"github.com/n3sty/focus/internal/notify"
+	"github.com/n3sty/focus/internal/pomodoro"
+	"github.com/n3sty/focus/internal/reminder"
+	"github.com/n3sty/focus/internal/session"
+)
+
+var start = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+// fakeSessions keeps the session in memory, copying it on every load and
+// save like the files on disk would
+type fakeSessions struct {
+	mu    sync.Mutex
+	sess  *session.Session
+	saves int
+}
+
+func (f *fakeSessions) Load() (*session.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sess == nil {
+		return nil, os.ErrNotExist
+	}
+	return f.sess.Clone(), nil
+}
+
+func (f *fakeSessions) Save(sess *session.Session) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sess = sess.Clone()
+	f.saves++
+	return nil
+}
+
+// remove deletes the session, like 'focus end' does
+func (f *fakeSessions) remove() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sess = nil
+}
+
+// fakeNotifier remembers what it was asked to show
+type fakeNotifier struct {
+	sent []notify.Notification
+}
+
+func (f *fakeNotifier) Notify(n notify.Notification) error {
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+// harness wires a watcher to fakes
+type harness struct {
+	clock    *clock.Fake
+	ticks    chan time.Time
+	signals  chan os.Signal
+	sessions *fakeSessions
+	notifier *fakeNotifier
+	events   []events.Event
+	hooks    []string
+	watcher  *Watcher
+}
+
+func newHarness(t *testing.T, policy reminder.Policy) *harness {
+	t.Helper()
+
+	h := &harness{
+		clock:   &clock.Fake{T: start},
+		ticks:   make(chan time.Time),
+		signals: make(chan os.Signal, 1),
+		sessions: &fakeSessions{sess: &session.Session{
+			ID:        "write-the-parser",
+			Task:      "Write the parser",
+			StartTime: start,
+			TimeBox:   "1h",
+			Branch:    "focus/write-the-parser",
+			Status:    "active",
+		}},
+		notifier: &fakeNotifier{},
+	}
+
+	cfg := Config{Reminders: policy}
+	h.watcher = New(cfg, Deps{
+		Clock:    h.clock,
+		Ticks:    h.ticks,
+		Signals:  h.signals,
+		Sessions: h.sessions,
+		Notifier: h.notifier,
+		Record: func(e events.Event) error {
+			h.events = append(h.events, e)
+			return nil
+		},
+		RunHook: func(event string, sess *session.Session, env map[string]string) error {
+			h.hooks = append(h.hooks, event)
+			return nil
+		},
+	})
+	return h
+}
+
+// advance moves the clock forward a minute at a time, ticking after each
+func (h *harness) advance(t *testing.T, d time.Duration) {
+	t.Helper()
+	for end := h.clock.Now().Add(d); h.clock.Now().Before(end); {
+		h.clock.Advance(time.Minute)
+		if !h.watcher.Tick() {
+			t.Fatalf("watcher stopped at %s", h.clock.Now().Format("15:04"))
+		}
+	}
+}
+
+func (h *harness) titles() []string {
+	var titles []string
+	for _, n := range h.notifier.sent {
+		titles = append(titles, n.Title)
+	}
+	return titles
+}
+
+func TestCheckReminders(t *testing.T) {
+	h := newHarness(t, reminder.Policy{Interval: 20 * time.Minute})
+	h.watcher.Tick()
+
+	h.advance(t, 19*time.Minute)
+	if len(h.notifier.sent) != 0 {
+		t.Fatalf("sent %v before the first interval", h.titles())
+	}
+
+	h.advance(t, 21*time.Minute)
+	if got := h.titles(); len(got) != 2 || got[0] != "🎯 Focus Check" {
+		t.Fatalf("sent %v, want two focus checks", got)
+	}
+	if n := h.notifier.sent[0]; n.Urgency != notify.UrgencyNormal || len(n.Actions) == 0 {
+		t.Errorf("check reminder = %+v, want normal urgency with buttons", n)
+	}
+
+	// Prompts are saved so 'focus check' can measure how long they waited
+	if h.sessions.sess.PromptsSent != 2 {
+		t.Errorf("prompts sent = %d, want 2", h.sessions.sess.PromptsSent)
+	}
+	reminders := 0
+	for _, e := range h.events {
+		if e.Type == events.ReminderSent && e.Data["kind"] == "check" {
+			reminders++
+		}
+	}
+	if reminders != 2 {
+		t.Errorf("logged %d check reminders, want 2", reminders)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	h := newHarness(t, reminder.Policy{
+		Warnings: []time.Duration{10 * time.Minute},
+		Nags:     []time.Duration{15 * time.Minute},
+	})
+	h.watcher.Tick()
+
+	h.advance(t, 50*time.Minute)
+	if got := h.titles(); len(got) != 1 || got[0] != "⏳ Timebox Ending Soon" {
+		t.Fatalf("sent %v, want one warning", got)
+	}
+	if len(h.hooks) != 0 {
+		t.Fatalf("ran hooks %v before expiry", h.hooks)
+	}
+
+	h.advance(t, 10*time.Minute)
+	last := h.notifier.sent[len(h.notifier.sent)-1]
+	if last.Title != "⏱️ Focus Timebox Expired!" || last.Urgency != notify.UrgencyCritical {
+		t.Fatalf("last notification = %+v, want a critical expiry", last)
+	}
+	if len(h.hooks) != 1 || h.hooks[0] != hooks.OnExpire {
+		t.Fatalf("ran hooks %v, want on-expire once", h.hooks)
+	}
+
+	h.advance(t, 15*time.Minute)
+	last = h.notifier.sent[len(h.notifier.sent)-1]
+	if last.Title != "⏰ Over Timebox" {
+		t.Errorf("last notification = %q, want a nag", last.Title)
+	}
+	if len(h.hooks) != 1 {
+		t.Errorf("ran hooks %v, want on-expire only once", h.hooks)
+	}
+}
+
+func TestSnoozeFromNotification(t *testing.T) {
+	h := newHarness(t, reminder.Policy{Interval: 20 * time.Minute})
+	h.watcher.Tick()
+
+	h.advance(t, 15*time.Minute)
+	h.watcher.HandleAction(notify.ActionSnooze)
+
+	until := h.sessions.sess.SnoozedUntil
+	if until == nil || !until.Equal(start.Add(25*time.Minute)) {
+		t.Fatalf("snoozed until %v, want 09:25 on the watcher clock", until)
+	}
+
+	h.advance(t, 9*time.Minute)
+	if len(h.notifier.sent) != 0 {
+		t.Fatalf("sent %v while snoozed", h.titles())
+	}
+	h.advance(t, time.Minute)
+	if len(h.notifier.sent) != 1 {
+		t.Errorf("sent %v, want the check once the snooze ends", h.titles())
+	}
+}
+
+func TestIdleAfterBreak(t *testing.T) {
+	h := newHarness(t, reminder.Policy{})
+	h.sessions.sess.Pomodoro = pomodoro.New(pomodoro.DefaultPlan, start)
+	h.watcher.cfg.IdleThreshold = 10 * time.Minute
+	// Last keystroke at 9:20, then away through the 9:25 break and after it
+	h.watcher.deps.Activity = func() time.Time { return start.Add(20 * time.Minute) }
+	h.watcher.Tick()
+
+	h.advance(t, 50*time.Minute)
+
+	pauses := h.sessions.sess.Pauses
+	if len(pauses) != 2 || pauses[0].Reason != "break" || pauses[1].Reason != "idle" {
+		t.Fatalf("pauses = %+v, want the break then idle", pauses)
+	}
+	if !pauses[1].Start.Equal(*pauses[0].End) {
+		t.Errorf("idle from %s, want from the end of the break at %s",
+			pauses[1].Start.Format("15:04"), pauses[0].End.Format("15:04"))
+	}
+	if got := h.sessions.sess.FocusedTime(h.clock.Now()); got != 25*time.Minute {
+		t.Errorf("focused %s, want 25m: the break counted once", got)
+	}
+}
+
+func TestIdleAfterMeeting(t *testing.T) {
+	h := newHarness(t, reminder.Policy{})
+	h.watcher.cfg.IdleThreshold = 10 * time.Minute
+	h.watcher.cfg.Calendar = Calendar{AutoPause: true}
+	standup := calendar.Event{UID: "standup", Summary: "Standup", Start: start.Add(20 * time.Minute), End: start.Add(40 * time.Minute)}
+	h.watcher.deps.Meetings = func(from, to time.Time) []calendar.Event {
+		return calendar.Between([]calendar.Event{standup}, from, to)
+	}
+	// Last keystroke at 9:15, then away through the meeting and after it
+	h.watcher.deps.Activity = func() time.Time { return start.Add(15 * time.Minute) }
+	h.watcher.Tick()
+
+	h.advance(t, time.Hour)
+
+	pauses := h.sessions.sess.Pauses
+	if len(pauses) != 2 || pauses[0].Reason != "meeting" || pauses[1].Reason != "idle" {
+		t.Fatalf("pauses = %+v, want the meeting then idle", pauses)
+	}
+	if !pauses[1].Start.Equal(*pauses[0].End) {
+		t.Errorf("idle from %s, want from the end of the meeting at %s",
+			pauses[1].Start.Format("15:04"), pauses[0].End.Format("15:04"))
+	}
+	if got := h.sessions.sess.FocusedTime(h.clock.Now()); got != 20*time.Minute {
+		t.Errorf("focused %s, want 20m: the meeting counted once", got)
+	}
+}
+
+// countingPublisher counts flushes
+type countingPublisher struct {
+	flushes int
+}
+
+func (p *countingPublisher) Publish(e notify.Event) error { return nil }
+func (p *countingPublisher) Flush() error                 { p.flushes++; return nil }
+
+func TestFlushesWhenEventsAreQueued(t *testing.T) {
+	h := newHarness(t, reminder.Policy{})
+	publisher := &countingPublisher{}
+	queued := 0
+	h.watcher.deps.Publisher = publisher
+	h.watcher.deps.Queued = func() int { return queued }
+
+	h.watcher.Tick()
+	h.advance(t, 2*time.Minute)
+	if publisher.flushes != 1 {
+		t.Fatalf("flushed %d times, want once on the first tick", publisher.flushes)
+	}
+
+	// 'focus dash' pauses the session and queues the event
+	h.sessions.sess.Status = "paused"
+	queued = 1
+	h.advance(t, time.Minute)
+	if publisher.flushes != 2 {
+		t.Errorf("flushed %d times, want straight after the event was queued", publisher.flushes)
+	}
+
+	// Still failing: retried on the flush interval, not every tick
+	h.advance(t, 4*time.Minute)
+	if publisher.flushes != 2 {
+		t.Errorf("flushed %d times before the interval", publisher.flushes)
+	}
+	h.advance(t, time.Minute)
+	if publisher.flushes != 3 {
+		t.Errorf("flushed %d times, want a retry after %s", publisher.flushes, flushInterval)
+	}
+
+	// 'focus end' queues the last event and stops the watcher
+	queued = 2
+	h.sessions.remove()
+	close(h.ticks)
+	if err := h.watcher.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if publisher.flushes != 4 {
+		t.Errorf("flushed %d times, want the last event sent on the way out", publisher.flushes)
+	}
+}
+
+func TestPausedSessionIsLeftAlone(t *testing.T) {
+	h := newHarness(t, reminder.Policy{Interval: 10 * time.Minute})
+	h.sessions.sess.Status = "paused"
+	h.watcher.Tick()
+
+	h.advance(t, 2*time.Hour)
+	h.watcher.HandleAction(notify.ActionDrift)
+
+	if len(h.notifier.sent) != 0 || h.sessions.saves != 0 || len(h.events) != 0 {
+		t.Errorf("paused session: sent %v, saved %d times, logged %d events",
+			h.titles(), h.sessions.saves, len(h.events))
+	}
+}
+
+func TestStopsWhenSessionDisappears(t *testing.T) {
+	h := newHarness(t, reminder.DefaultPolicy())
+
+	done := make(chan error, 1)
+	go func() { done <- h.watcher.Run() }()
+
+	h.ticks <- start
+	h.sessions.remove()
+	h.ticks <- start.Add(time.Minute)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returned %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watcher kept running without a session")
+	}
+}
+
+func TestStopsOnSignal(t *testing.T) {
+	h := newHarness(t, reminder.DefaultPolicy())
+
+	done := make(chan error, 1)
+	go func() { done <- h.watcher.Run() }()
+
+	h.ticks <- start
+	h.signals <- os.Interrupt
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returned %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watcher ignored the signal")
+	}
+}
+
+func TestStopsWhenTicksEnd(t *testing.T) {
+	h := newHarness(t, reminder.DefaultPolicy())
+	close(h.ticks)
+
+	if err := h.watcher.Run(); err != nil {
+		t.Errorf("Run returned %v", err)
+	}
+}
+
+func TestLoadErrorStopsTick(t *testing.T) {
+	h := newHarness(t, reminder.DefaultPolicy())
+	h.watcher.deps.Sessions = failingSessions{}
+
+	if h.watcher.Tick() {
+		t.Error("Tick kept going after the session failed to load")
+	}
+}
+
+type failingSessions struct{}
+
+func (failingSessions) Load() (*session.Session, error)  { return nil, errors.New("corrupt") }
+func (failingSessions) Save(sess *session.Session) error { return nil }
@@ -0,0 +1,153 @@
+package worklog
+
+import (
+	"sort"
+	"time"
+
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/session"
+)
+
+// Interval is a stretch of time spent working on a session
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Duration returns how long the interval lasted
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// Intervals splits a session into the stretches it was worked on: from its
+// start until it ended (or now), minus idle time and breaks recorded on the
+// session and the times it sat paused according to its event log
+func Intervals(sess *session.Session, log []events.Event, now time.Time) []Interval {
+	end := now
+	if sess.EndTime != nil {
+		end = *sess.EndTime
+	}
+
+	var gaps []Interval
+	for _, p := range sess.Pauses {
+		gap := Interval{Start: p.Start, End: end}
+		if p.End != nil {
+			gap.End = *p.End
+		}
+		gaps = append(gaps, gap)
+	}
+
+	var pausedAt *time.Time
+	for _, e := range log {
+		switch e.Type {
+		case events.SessionPaused:
+			if pausedAt == nil {
+				at := e.Time
+				pausedAt = &at
+			}
+		case events.SessionResumed:
+			if pausedAt != nil {
+				gaps = append(gaps, Interval{Start: *pausedAt, End: e.Time})
+				pausedAt = nil
+			}
+		}
+	}
+	if pausedAt != nil {
+		gaps = append(gaps, Interval{Start: *pausedAt, End: end})
+	}
+
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i].Start.Before(gaps[j].Start) })
+
+	var intervals []Interval
+	cursor := sess.StartTime
+	for _, gap := range gaps {
+		if gap.Start.After(end) {
+			break
+		}
+		if gap.Start.After(cursor) {
+			intervals = append(intervals, Interval{Start: cursor, End: gap.Start})
+		}
+		if gap.End.After(cursor) {
+			cursor = gap.End
+		}
+	}
+	if end.After(cursor) {
+		intervals = append(intervals, Interval{Start: cursor, End: end})
+	}
+
+	return intervals
+}
+
+// Load reads the session's event log and returns its intervals
+func Load(sess *session.Session, now time.Time) ([]Interval, error) {
+	log, err := events.Load(sess.ID)
+	if err != nil {
+		return nil, err
+	}
+	return Intervals(sess, log, now), nil
+}
+
+// Entry is a session with the stretches it was worked on
+type Entry struct {
+	Session   *session.Session
+	Intervals []Interval
+}
+
+// LoadAll returns every ended, paused and active session with its
+// intervals, oldest first
+func LoadAll(now time.Time) ([]Entry, error) {
+	sessions, err := session.ListArchived()
+	if err != nil {
+		return nil, err
+	}
+
+	paused, err := session.ListPaused()
+	if err != nil {
+		return nil, err
+	}
+	sessions = append(sessions, paused...)
+
+	if active, err := session.Load(); err == nil && active.Status == "active" {
+		sessions = append(sessions, active)
+	}
+
+	entries := make([]Entry, 0, len(sessions))
+	for _, sess := range sessions {
+		intervals, err := Load(sess, now)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{Session: sess, Intervals: intervals})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Session.StartTime.Before(entries[j].Session.StartTime)
+	})
+	return entries, nil
+}
+
+// Total adds up the intervals
+func Total(intervals []Interval) time.Duration {
+	var total time.Duration
+	for _, i := range intervals {
+		total += i.Duration()
+	}
+	return total
+}
+
+// Clip returns the parts of the intervals that fall between from and to
+func Clip(intervals []Interval, from, to time.Time) []Interval {
+	var clipped []Interval
+	for _, i := range intervals {
+		if i.Start.Before(from) {
+			i.Start = from
+		}
+		if i.End.After(to) {
+			i.End = to
+		}
+		if i.End.After(i.Start) {
+			clipped = append(clipped, i)
+		}
+	}
+	return clipped
+}
@@ -0,0 +1,126 @@
+package worklog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n3sty/focus/internal/events"
+	"github.com/n3sty/focus/internal/session"
+)
+
+var start = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+// at is start plus minutes
+func at(minutes int) time.Time {
+	return start.Add(time.Duration(minutes) * time.Minute)
+}
+
+func atPtr(minutes int) *time.Time {
+	t := at(minutes)
+	return &t
+}
+
+// spans renders intervals as minutes after start, e.g. "0-30 45-60"
+func spans(intervals []Interval) string {
+	var s []string
+	for _, i := range intervals {
+		s = append(s, fmt.Sprintf("%d-%d", int(i.Start.Sub(start).Minutes()), int(i.End.Sub(start).Minutes())))
+	}
+	return strings.Join(s, " ")
+}
+
+func TestIntervals(t *testing.T) {
+	paused := func(minutes int) events.Event {
+		return events.Event{Type: events.SessionPaused, Time: at(minutes)}
+	}
+	resumed := func(minutes int) events.Event {
+		return events.Event{Type: events.SessionResumed, Time: at(minutes)}
+	}
+
+	tests := []struct {
+		name   string
+		pauses []session.Pause
+		log    []events.Event
+		ended  *time.Time
+		want   string
+	}{
+		{"no pauses", nil, nil, nil, "0-60"},
+		{
+			"idle and break",
+			[]session.Pause{
+				{Start: at(10), End: atPtr(20), Reason: "idle"},
+				{Start: at(40), End: atPtr(45), Reason: "break"},
+			},
+			nil, nil,
+			"0-10 20-40 45-60",
+		},
+		{"open pause runs to now", []session.Pause{{Start: at(50), Reason: "idle"}}, nil, nil, "0-50"},
+		{"pause and resume events", nil, []events.Event{paused(15), resumed(30)}, nil, "0-15 30-60"},
+		{"still paused", nil, []events.Event{paused(15), resumed(30), paused(50)}, nil, "0-15 30-50"},
+		{
+			"repeated pause events count from the first",
+			nil,
+			[]events.Event{paused(15), paused(20), resumed(30), resumed(35)},
+			nil,
+			"0-15 30-60",
+		},
+		{
+			"overlapping gaps",
+			[]session.Pause{
+				{Start: at(10), End: atPtr(30), Reason: "idle"},
+				{Start: at(20), End: atPtr(25), Reason: "break"},
+				{Start: at(28), End: atPtr(35), Reason: "paused"},
+			},
+			[]events.Event{paused(28), resumed(35)},
+			nil,
+			"0-10 35-60",
+		},
+		{"pause at the start", []session.Pause{{Start: at(0), End: atPtr(5)}}, nil, nil, "5-60"},
+		{"ended session stops at its end", nil, nil, atPtr(40), "0-40"},
+		{"pause after the end", []session.Pause{{Start: at(45), End: atPtr(50)}}, nil, atPtr(40), "0-40"},
+		{"open pause stops at the end", []session.Pause{{Start: at(30)}}, nil, atPtr(40), "0-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess := &session.Session{StartTime: start, Pauses: tt.pauses, EndTime: tt.ended}
+			if got := spans(Intervals(sess, tt.log, at(60))); got != tt.want {
+				t.Errorf("Intervals = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClip(t *testing.T) {
+	intervals := []Interval{
+		{Start: at(0), End: at(30)},
+		{Start: at(40), End: at(60)},
+		{Start: at(90), End: at(120)},
+	}
+
+	tests := []struct {
+		name     string
+		from, to int
+		want     string
+	}{
+		{"everything", -10, 200, "0-30 40-60 90-120"},
+		{"cuts both ends", 10, 100, "10-30 40-60 90-100"},
+		{"inside one interval", 45, 50, "45-50"},
+		{"in a gap", 30, 40, ""},
+		{"touching an edge", 60, 90, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spans(Clip(intervals, at(tt.from), at(tt.to))); got != tt.want {
+				t.Errorf("Clip = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if total := Total(Clip(intervals, at(10), at(100))); total != 50*time.Minute {
+		t.Errorf("Total = %s, want 50m", total)
+	}
+}